@@ -0,0 +1,141 @@
+// Command atlasctl is a headless CLI over the same service lifecycle the
+// Atlas Control Panel TUI manages, for CI pipelines and remote SSH sessions
+// where a Bubble Tea TUI can't render.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/KumarVandit/data_hackathon/internal/probe"
+	"github.com/KumarVandit/data_hackathon/pkg/lifecycle"
+)
+
+const probesConfigPath = "config/probes.yaml"
+
+// loadProbeConfig reads config/probes.yaml, falling back to
+// probe.DefaultConfig so readiness checks still work out of the box.
+func loadProbeConfig() *probe.Config {
+	cfg, err := probe.LoadConfig(probesConfigPath)
+	if err != nil {
+		return probe.DefaultConfig()
+	}
+	return cfg
+}
+
+func printLog(line string) {
+	fmt.Println(line)
+}
+
+func main() {
+	root := &cobra.Command{
+		Use:   "atlasctl",
+		Short: "Headless control for the Atlas service stack",
+	}
+	root.AddCommand(upCmd(), downCmd(), restartCmd(), statusCmd(), logsCmd())
+
+	if err := root.Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func upCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "up",
+		Short: "Start FalkorDB, Ollama, and Graphiti MCP",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			msg, err := lifecycle.Up(context.Background(), lifecycle.CoreServices, printLog)
+			if err != nil {
+				return err
+			}
+			fmt.Println(msg)
+			return nil
+		},
+	}
+}
+
+func downCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "down",
+		Short: "Stop and remove every compose service",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			msg, err := lifecycle.Down(context.Background(), printLog)
+			if err != nil {
+				return err
+			}
+			fmt.Println(msg)
+			return nil
+		},
+	}
+}
+
+func restartCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "restart <service>",
+		Short: "Restart a single service",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			msg, err := lifecycle.Restart(context.Background(), args[0])
+			if err != nil {
+				return err
+			}
+			fmt.Println(msg)
+			return nil
+		},
+	}
+}
+
+func statusCmd() *cobra.Command {
+	var asJSON bool
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the live status of every service",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			statuses, err := lifecycle.Status(context.Background())
+			if err != nil {
+				return err
+			}
+			if asJSON {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(statuses)
+			}
+			for _, s := range statuses {
+				state := "stopped"
+				if s.Running {
+					state = "running"
+				}
+				fmt.Printf("%-16s %-8s health=%-10s uptime=%-10s id=%s\n", s.Name, state, s.Health, s.Uptime, s.ContainerID)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&asJSON, "json", false, "print status as JSON")
+	return cmd
+}
+
+func logsCmd() *cobra.Command {
+	var tail int
+	cmd := &cobra.Command{
+		Use:   "logs <service>",
+		Short: "Print a service's recent container logs",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			lines, err := lifecycle.Logs(context.Background(), args[0], tail)
+			if err != nil {
+				return err
+			}
+			for _, line := range lines {
+				fmt.Println(line)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().IntVar(&tail, "tail", 100, "number of lines to show")
+	return cmd
+}
@@ -0,0 +1,177 @@
+// Package analyzer inspects a point-in-time Snapshot of the control panel's
+// state and emits actionable warnings, the way OpenShift's `oc status`
+// describer flags a project's misconfigurations. Checks are pluggable
+// (func(Snapshot) []Finding) so new ones can be added without touching the
+// TUI.
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// Severity ranks how urgent a Finding is.
+type Severity int
+
+const (
+	Info Severity = iota
+	Warn
+	Error
+)
+
+func (s Severity) String() string {
+	switch s {
+	case Info:
+		return "info"
+	case Warn:
+		return "warn"
+	case Error:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+// Finding is one actionable warning surfaced to the user.
+type Finding struct {
+	Severity    Severity
+	Service     string
+	Message     string
+	Remediation string // human-readable suggestion, e.g. "press R to restart"
+}
+
+// ServiceInfo is the subset of main.model's service state a Check needs.
+type ServiceInfo struct {
+	Name      string
+	Status    string // "running" / "stopped"
+	Health    string // "healthy" / "" / other
+	Port      string // may be a composite string like "5173 (UI) / 8001 (API)"
+	DependsOn []string
+}
+
+// SystemInfo is the subset of main.model's systemStats a Check needs.
+type SystemInfo struct {
+	DiskPercent float64
+}
+
+// Snapshot bundles the current state a Check needs to reason about, without
+// depending on package main's unexported types.
+type Snapshot struct {
+	Services map[string]ServiceInfo
+	System   SystemInfo
+	LogTails map[string][]string
+}
+
+// Check inspects a Snapshot and returns zero or more Findings.
+type Check func(Snapshot) []Finding
+
+// DefaultChecks returns the built-in check set the TUI runs every tick.
+func DefaultChecks() []Check {
+	return []Check{
+		CheckUnhealthyDependency,
+		CheckPortUnreachable,
+		CheckDiskUsage,
+	}
+}
+
+// Run executes every check against snap and concatenates the findings.
+func Run(snap Snapshot, checks []Check) []Finding {
+	var findings []Finding
+	for _, check := range checks {
+		findings = append(findings, check(snap)...)
+	}
+	return findings
+}
+
+// CheckUnhealthyDependency flags a running service whose declared
+// dependency reports a health other than "healthy" or "running" (Docker's
+// value for a running container with no healthcheck). A dependency with no
+// health reported at all is not flagged.
+func CheckUnhealthyDependency(snap Snapshot) []Finding {
+	var findings []Finding
+	for _, svc := range snap.Services {
+		if svc.Status != "running" {
+			continue
+		}
+		for _, depName := range svc.DependsOn {
+			dep, ok := snap.Services[depName]
+			if !ok {
+				continue
+			}
+			if dep.Health != "" && dep.Health != "healthy" && dep.Health != "running" {
+				findings = append(findings, Finding{
+					Severity:    Warn,
+					Service:     svc.Name,
+					Message:     fmt.Sprintf("%s is running but depends on %s, which is unhealthy", svc.Name, depName),
+					Remediation: fmt.Sprintf("select %s and press R to restart it", depName),
+				})
+			}
+		}
+	}
+	return findings
+}
+
+// CheckPortUnreachable flags a service reported as running whose port
+// isn't accepting TCP connections.
+func CheckPortUnreachable(snap Snapshot) []Finding {
+	var findings []Finding
+	for _, svc := range snap.Services {
+		if svc.Status != "running" {
+			continue
+		}
+		port := firstNumericPort(svc.Port)
+		if port == "" {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+		conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", "localhost:"+port)
+		cancel()
+		if err != nil {
+			findings = append(findings, Finding{
+				Severity:    Error,
+				Service:     svc.Name,
+				Message:     fmt.Sprintf("%s container is running but port %s is not reachable", svc.Name, port),
+				Remediation: "press R to restart",
+			})
+			continue
+		}
+		conn.Close()
+	}
+	return findings
+}
+
+// firstNumericPort extracts the first purely-numeric token from a port
+// string, which may be a composite like "5173 (UI) / 8001 (API)".
+func firstNumericPort(port string) string {
+	for _, field := range strings.Fields(port) {
+		field = strings.Trim(field, "()")
+		isNumeric := field != ""
+		for _, r := range field {
+			if r < '0' || r > '9' {
+				isNumeric = false
+				break
+			}
+		}
+		if isNumeric {
+			return field
+		}
+	}
+	return ""
+}
+
+// CheckDiskUsage flags high disk usage, which can cause FalkorDB's
+// persistence (RDB/AOF snapshots) to fail.
+func CheckDiskUsage(snap Snapshot) []Finding {
+	if snap.System.DiskPercent <= 90 {
+		return nil
+	}
+	return []Finding{{
+		Severity:    Error,
+		Service:     "FalkorDB",
+		Message:     fmt.Sprintf("Disk usage %.0f%% — FalkorDB persistence may fail", snap.System.DiskPercent),
+		Remediation: "free up disk space",
+	}}
+}
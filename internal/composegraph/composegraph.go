@@ -0,0 +1,130 @@
+// Package composegraph derives the service dependency graph directly from
+// docker-compose.yml's depends_on blocks via compose-go, rather than the
+// hand-maintained Go maps that used to drift from the compose file whenever
+// a service was added or its dependencies changed.
+package composegraph
+
+import (
+	composetypes "github.com/compose-spec/compose-go/types"
+)
+
+// Condition is the depends_on condition qualifier compose-go parses out of
+// docker-compose.yml (condition: service_healthy | service_started |
+// service_completed_successfully). It decides whether a caller just waits
+// for a dependency to be running or runs its full readiness probe before
+// starting the dependent service.
+type Condition string
+
+const (
+	ConditionStarted               Condition = "service_started"
+	ConditionHealthy               Condition = "service_healthy"
+	ConditionCompletedSuccessfully Condition = "service_completed_successfully"
+)
+
+// Edge is one service's dependency on another, carrying the condition
+// compose-go attached to it. A depends_on entry with no condition qualifier
+// defaults to ConditionStarted, matching compose's own default.
+type Edge struct {
+	Service   string
+	Condition Condition
+}
+
+// Graph is the dependency DAG parsed out of a compose project's depends_on
+// blocks.
+type Graph struct {
+	edges map[string][]Edge // service -> its direct dependencies
+	names []string          // declaration order, used as a tie-breaker
+}
+
+// New builds a Graph from every service in project.
+func New(project *composetypes.Project) *Graph {
+	g := &Graph{
+		edges: make(map[string][]Edge, len(project.Services)),
+		names: project.ServiceNames(),
+	}
+	for _, svc := range project.Services {
+		for depName, dep := range svc.DependsOn {
+			cond := Condition(dep.Condition)
+			if cond == "" {
+				cond = ConditionStarted
+			}
+			g.edges[svc.Name] = append(g.edges[svc.Name], Edge{Service: depName, Condition: cond})
+		}
+	}
+	return g
+}
+
+// DependsOn returns the direct dependency edges of name.
+func (g *Graph) DependsOn(name string) []Edge {
+	return g.edges[name]
+}
+
+// Batches groups every service in the graph into Kahn's-algorithm
+// topological batches: every service in a batch depends only on services
+// placed in earlier batches, so a caller can start a whole batch
+// concurrently (e.g. via tea.Batch) instead of one service at a time. A
+// depends_on cycle can't be ordered, so any services still left once no
+// zero-indegree service remains are dumped into one final batch rather than
+// looping forever.
+func (g *Graph) Batches() [][]string {
+	indegree := make(map[string]int, len(g.names))
+	dependents := make(map[string][]string, len(g.names))
+	for _, name := range g.names {
+		indegree[name] = 0
+	}
+	for name, edges := range g.edges {
+		indegree[name] += len(edges)
+		for _, e := range edges {
+			dependents[e.Service] = append(dependents[e.Service], name)
+		}
+	}
+
+	done := make(map[string]bool, len(g.names))
+	var batches [][]string
+	for len(done) < len(g.names) {
+		var batch []string
+		for _, name := range g.names {
+			if !done[name] && indegree[name] == 0 {
+				batch = append(batch, name)
+			}
+		}
+		if len(batch) == 0 {
+			for _, name := range g.names {
+				if !done[name] {
+					batch = append(batch, name)
+				}
+			}
+		}
+		for _, name := range batch {
+			done[name] = true
+			for _, dependent := range dependents[name] {
+				indegree[dependent]--
+			}
+		}
+		batches = append(batches, batch)
+	}
+	return batches
+}
+
+// TransitiveDeps returns every service name transitively required to start
+// name, dependency-first, each paired with the condition edge that reached
+// it. This is the set a caller like startServiceIntelligent walks, honoring
+// each edge's own condition, before starting name itself.
+func (g *Graph) TransitiveDeps(name string) []Edge {
+	var out []Edge
+	seen := map[string]bool{name: true}
+
+	var visit func(n string)
+	visit = func(n string) {
+		for _, e := range g.edges[n] {
+			if seen[e.Service] {
+				continue
+			}
+			seen[e.Service] = true
+			visit(e.Service)
+			out = append(out, e)
+		}
+	}
+	visit(name)
+	return out
+}
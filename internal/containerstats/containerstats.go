@@ -0,0 +1,241 @@
+// Package containerstats streams live CPU/memory/network/block-I/O stats
+// for every running compose container via the Docker Engine API's
+// `/containers/{id}/stats?stream=true` endpoint, replacing the
+// `docker stats --no-stream` shell-out the dashboard used to poll every
+// tick. It's modeled on the Docker CLI's own collector
+// (cli/command/container/stats_helpers.go): one goroutine per container
+// decodes a stream of JSON stat frames, a mutex-protected registry tracks
+// which containers already have a collector running, and a Docker events
+// subscription adds newly started containers and evicts ones that stop —
+// the same "evict stopped containers" behavior moby's own stats command
+// has.
+package containerstats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/client"
+
+	"github.com/KumarVandit/data_hackathon/internal/dockerapi"
+)
+
+// Stat is one container's most recent stats sample.
+type Stat struct {
+	CPUPercent    float64
+	MemPercent    float64
+	MemUsage      string
+	MemUsageBytes uint64
+	Status        string
+	NetRx         uint64
+	NetTx         uint64
+	BlockRead     uint64
+	BlockWrite    uint64
+	Pids          uint64
+}
+
+// Update is one incremental sample pushed onto the channel passed to Start.
+// Removed is set instead of Stat being populated when a container's stream
+// ends (it stopped or was removed), so the TUI can evict it from its map.
+type Update struct {
+	Name    string
+	Stat    Stat
+	Removed bool
+}
+
+// Start seeds updates with every currently running container and begins
+// streaming each one's stats into it, the same way otlpsink.Start streams
+// OTLP events into its own channels. It also subscribes to Docker's
+// container events so started containers are picked up and stopped ones
+// are evicted automatically. Start returns once the initial container list
+// is seeded; streaming continues in background goroutines until ctx is
+// cancelled.
+func Start(ctx context.Context, updates chan<- Update) error {
+	cli, err := dockerapi.Client()
+	if err != nil {
+		return fmt.Errorf("containerstats: %w", err)
+	}
+
+	c := &collector{watched: make(map[string]bool), updates: updates}
+
+	f := filters.NewArgs()
+	f.Add("status", "running")
+	running, err := cli.ContainerList(ctx, types.ContainerListOptions{Filters: f})
+	if err != nil {
+		return fmt.Errorf("containerstats: list containers: %w", err)
+	}
+	for _, ctr := range running {
+		if len(ctr.Names) == 0 {
+			continue
+		}
+		c.watch(ctx, ctr.ID, strings.TrimPrefix(ctr.Names[0], "/"))
+	}
+
+	go c.watchEvents(ctx, cli)
+	return nil
+}
+
+// collector tracks which containers already have a streaming goroutine so a
+// stray duplicate "start" event doesn't spawn a second one.
+type collector struct {
+	mu      sync.Mutex
+	watched map[string]bool // container ID -> streaming
+	updates chan<- Update
+}
+
+// watch starts a goroutine decoding id's stats stream, pushing a Stat
+// update per frame until the stream ends, at which point it pushes a
+// Removed update and drops id from the registry.
+func (c *collector) watch(ctx context.Context, id, name string) {
+	c.mu.Lock()
+	if c.watched[id] {
+		c.mu.Unlock()
+		return
+	}
+	c.watched[id] = true
+	c.mu.Unlock()
+
+	go func() {
+		defer func() {
+			c.mu.Lock()
+			delete(c.watched, id)
+			c.mu.Unlock()
+		}()
+
+		cli, err := dockerapi.Client()
+		if err != nil {
+			return
+		}
+		resp, err := cli.ContainerStats(ctx, id, true)
+		if err != nil {
+			return
+		}
+		defer resp.Body.Close()
+
+		dec := json.NewDecoder(resp.Body)
+		for {
+			var frame types.StatsJSON
+			if err := dec.Decode(&frame); err != nil {
+				c.updates <- Update{Name: name, Removed: true}
+				return
+			}
+			c.updates <- Update{Name: name, Stat: toStat(frame)}
+		}
+	}()
+}
+
+// watchEvents subscribes to Docker's container event stream and adds or
+// evicts a streaming collector as containers start and stop.
+func (c *collector) watchEvents(ctx context.Context, cli *client.Client) {
+	f := filters.NewArgs()
+	f.Add("type", "container")
+	msgs, errs := cli.Events(ctx, types.EventsOptions{Filters: f})
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case err, ok := <-errs:
+			if !ok || err != nil {
+				return
+			}
+		case ev, ok := <-msgs:
+			if !ok {
+				return
+			}
+			name := strings.TrimPrefix(ev.Actor.Attributes["name"], "/")
+			switch ev.Action {
+			case "start":
+				c.watch(ctx, ev.Actor.ID, name)
+			case "die", "stop", "kill":
+				c.updates <- Update{Name: name, Removed: true}
+			}
+		}
+	}
+}
+
+// toStat converts one raw stats frame into a Stat, using the standard
+// delta formulas: CPU% from the usage/system deltas between this frame and
+// the precpu_stats frame Docker includes alongside it, scaled by online
+// CPUs; mem% from usage minus page cache over the container's memory
+// limit; NetRx/NetTx summed across every interface; BlockRead/BlockWrite
+// summed across every io_service_bytes_recursive entry.
+func toStat(v types.StatsJSON) Stat {
+	var netRx, netTx uint64
+	for _, n := range v.Networks {
+		netRx += n.RxBytes
+		netTx += n.TxBytes
+	}
+
+	var blockRead, blockWrite uint64
+	for _, entry := range v.BlkioStats.IoServiceBytesRecursive {
+		switch strings.ToLower(entry.Op) {
+		case "read":
+			blockRead += entry.Value
+		case "write":
+			blockWrite += entry.Value
+		}
+	}
+
+	return Stat{
+		CPUPercent:    cpuPercent(v),
+		MemPercent:    memPercent(v),
+		MemUsage:      fmt.Sprintf("%s / %s", humanBytes(memUsage(v)), humanBytes(v.MemoryStats.Limit)),
+		MemUsageBytes: memUsage(v),
+		Status:        "running",
+		NetRx:         netRx,
+		NetTx:         netTx,
+		BlockRead:     blockRead,
+		BlockWrite:    blockWrite,
+		Pids:          v.PidsStats.Current,
+	}
+}
+
+func cpuPercent(v types.StatsJSON) float64 {
+	cpuDelta := float64(v.CPUStats.CPUUsage.TotalUsage) - float64(v.PreCPUStats.CPUUsage.TotalUsage)
+	systemDelta := float64(v.CPUStats.SystemUsage) - float64(v.PreCPUStats.SystemUsage)
+	if cpuDelta <= 0 || systemDelta <= 0 {
+		return 0
+	}
+	onlineCPUs := float64(v.CPUStats.OnlineCPUs)
+	if onlineCPUs == 0 {
+		onlineCPUs = float64(len(v.CPUStats.CPUUsage.PercpuUsage))
+	}
+	if onlineCPUs == 0 {
+		onlineCPUs = 1
+	}
+	return (cpuDelta / systemDelta) * onlineCPUs * 100
+}
+
+func memUsage(v types.StatsJSON) uint64 {
+	if v.MemoryStats.Usage < v.MemoryStats.Stats["cache"] {
+		return 0
+	}
+	return v.MemoryStats.Usage - v.MemoryStats.Stats["cache"]
+}
+
+func memPercent(v types.StatsJSON) float64 {
+	if v.MemoryStats.Limit == 0 {
+		return 0
+	}
+	return float64(memUsage(v)) / float64(v.MemoryStats.Limit) * 100
+}
+
+// humanBytes renders n as a short binary-unit string (1.0KiB-scale),
+// matching Docker's own MemUsage column.
+func humanBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := uint64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
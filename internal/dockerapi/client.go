@@ -0,0 +1,27 @@
+// Package dockerapi wraps the Docker Engine API client
+// (github.com/docker/docker/client) and a compose-go project loader, so the
+// control panel can inspect and drive container lifecycle directly instead
+// of forking `docker ps`/`docker compose`/`docker-compose` and parsing
+// their text output.
+package dockerapi
+
+import (
+	"sync"
+
+	"github.com/docker/docker/client"
+)
+
+var (
+	once      sync.Once
+	sharedCli *client.Client
+	sharedErr error
+)
+
+// Client returns the process-wide Docker Engine API client, created once on
+// first use from the standard DOCKER_HOST/DOCKER_* environment variables.
+func Client() (*client.Client, error) {
+	once.Do(func() {
+		sharedCli, sharedErr = client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	})
+	return sharedCli, sharedErr
+}
@@ -0,0 +1,332 @@
+package dockerapi
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/compose-spec/compose-go/loader"
+	composetypes "github.com/compose-spec/compose-go/types"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
+	"github.com/docker/go-connections/nat"
+)
+
+// LoadProject parses a docker-compose.yml with compose-go, the same loader
+// `docker compose` itself uses, giving us typed ServiceConfigs instead of
+// invoking the compose CLI as a subprocess.
+func LoadProject(path string) (*composetypes.Project, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("dockerapi: read %s: %w", path, err)
+	}
+	project, err := loader.Load(composetypes.ConfigDetails{
+		ConfigFiles: []composetypes.ConfigFile{{Filename: path, Content: data}},
+		Environment: envMap(os.Environ()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("dockerapi: parse %s: %w", path, err)
+	}
+	return project, nil
+}
+
+// Up creates and starts the named compose services if they aren't already
+// running, pulling their image first if it isn't present locally. log
+// receives progress lines the same way progress.Writer.VertexLog did for
+// the old `docker compose up -d` output.
+func Up(ctx context.Context, project *composetypes.Project, serviceNames []string, log func(string)) error {
+	cli, err := Client()
+	if err != nil {
+		return fmt.Errorf("dockerapi: %w", err)
+	}
+
+	networkName := defaultNetworkName(project)
+	if err := ensureNetwork(ctx, cli, networkName); err != nil {
+		return err
+	}
+
+	for _, name := range serviceNames {
+		svc, err := project.GetService(name)
+		if err != nil {
+			return fmt.Errorf("dockerapi: service %s not in compose project: %w", name, err)
+		}
+
+		containerName := svc.ContainerName
+		if containerName == "" {
+			containerName = name
+		}
+
+		if IsRunning(ctx, containerName) {
+			log(fmt.Sprintf("%s already running", containerName))
+			continue
+		}
+
+		if err := pullIfMissing(ctx, cli, svc.Image, log); err != nil {
+			return err
+		}
+
+		cfg := &container.Config{
+			Image: svc.Image,
+			Env:   envSlice(svc.Environment),
+			Cmd:   svc.Command,
+		}
+		hostCfg := &container.HostConfig{
+			PortBindings: portBindings(svc.Ports),
+			Binds:        bindMounts(svc.Volumes),
+		}
+		netCfg := &network.NetworkingConfig{
+			EndpointsConfig: map[string]*network.EndpointSettings{
+				networkName: {Aliases: []string{name}},
+			},
+		}
+
+		created, err := cli.ContainerCreate(ctx, cfg, hostCfg, netCfg, nil, containerName)
+		if err != nil {
+			return fmt.Errorf("dockerapi: create %s: %w", containerName, err)
+		}
+		if err := cli.ContainerStart(ctx, created.ID, types.ContainerStartOptions{}); err != nil {
+			return fmt.Errorf("dockerapi: start %s: %w", containerName, err)
+		}
+		log(fmt.Sprintf("started %s (%s)", containerName, shortID(created.ID)))
+	}
+	return nil
+}
+
+// Build builds the named compose services' images from their local build
+// context via the Engine API's ImageBuild, for services that declare a
+// `build:` section (as opposed to a plain `image:` to pull).
+func Build(ctx context.Context, project *composetypes.Project, serviceNames []string, log func(string)) error {
+	cli, err := Client()
+	if err != nil {
+		return fmt.Errorf("dockerapi: %w", err)
+	}
+
+	for _, name := range serviceNames {
+		svc, err := project.GetService(name)
+		if err != nil {
+			return fmt.Errorf("dockerapi: service %s not in compose project: %w", name, err)
+		}
+		if svc.Build == nil {
+			log(fmt.Sprintf("%s has no build section, skipping", name))
+			continue
+		}
+
+		buildCtx, err := tarBuildContext(svc.Build.Context)
+		if err != nil {
+			return fmt.Errorf("dockerapi: build context for %s: %w", name, err)
+		}
+
+		dockerfile := svc.Build.Dockerfile
+		if dockerfile == "" {
+			dockerfile = "Dockerfile"
+		}
+
+		if err := buildOne(ctx, cli, dockerfile, svc.Image, buildCtx); err != nil {
+			return fmt.Errorf("dockerapi: build %s: %w", name, err)
+		}
+		log(fmt.Sprintf("built %s", svc.Image))
+	}
+	return nil
+}
+
+// buildOne runs a single ImageBuild call in its own function so its response
+// body is closed as soon as this build finishes, rather than staying open
+// until every service in the loop has been built.
+func buildOne(ctx context.Context, cli *client.Client, dockerfile, image string, buildCtx io.Reader) error {
+	resp, err := cli.ImageBuild(ctx, buildCtx, types.ImageBuildOptions{
+		Dockerfile: dockerfile,
+		Tags:       []string{image},
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return readImageStream(resp.Body)
+}
+
+// Down stops and removes the named compose services' containers.
+func Down(ctx context.Context, project *composetypes.Project, serviceNames []string, log func(string)) error {
+	for _, name := range serviceNames {
+		svc, err := project.GetService(name)
+		if err != nil {
+			continue
+		}
+		containerName := svc.ContainerName
+		if containerName == "" {
+			containerName = name
+		}
+		if err := Stop(ctx, containerName, 10*time.Second); err != nil {
+			log(fmt.Sprintf("stop %s: %v", containerName, err))
+			continue
+		}
+		if err := Remove(ctx, containerName); err != nil {
+			log(fmt.Sprintf("remove %s: %v", containerName, err))
+		}
+	}
+	return nil
+}
+
+// defaultNetworkName returns the name `docker compose up` gives a project's
+// implicit default network, so containers started through the Engine API
+// land on the same user-defined network compose itself would create instead
+// of the DNS-less default bridge.
+func defaultNetworkName(project *composetypes.Project) string {
+	return project.Name + "_default"
+}
+
+// ensureNetwork creates networkName as a bridge network if it doesn't
+// already exist.
+func ensureNetwork(ctx context.Context, cli *client.Client, networkName string) error {
+	if _, err := cli.NetworkInspect(ctx, networkName, types.NetworkInspectOptions{}); err == nil {
+		return nil
+	}
+	if _, err := cli.NetworkCreate(ctx, networkName, types.NetworkCreate{Driver: "bridge"}); err != nil {
+		return fmt.Errorf("dockerapi: create network %s: %w", networkName, err)
+	}
+	return nil
+}
+
+func pullIfMissing(ctx context.Context, cli *client.Client, image string, log func(string)) error {
+	if _, _, err := cli.ImageInspectWithRaw(ctx, image); err == nil {
+		return nil
+	}
+	log(fmt.Sprintf("pulling %s...", image))
+	reader, err := cli.ImagePull(ctx, image, types.ImagePullOptions{})
+	if err != nil {
+		return fmt.Errorf("dockerapi: pull %s: %w", image, err)
+	}
+	defer reader.Close()
+	if err := readImageStream(reader); err != nil {
+		return fmt.Errorf("dockerapi: pull %s: %w", image, err)
+	}
+	return nil
+}
+
+// readImageStream decodes a Docker Engine API JSON-message stream, the
+// shape ImageBuild and ImagePull both respond with, and returns an error if
+// any message in it reports one. Both endpoints answer 200 OK even when
+// the build/pull itself fails — a bad Dockerfile, a missing base image, a
+// failed RUN step, a missing tag — so the only place that failure surfaces
+// is an "error"/"errorDetail" object inside the stream body, not a Go err
+// from the call itself.
+func readImageStream(r io.Reader) error {
+	decoder := json.NewDecoder(r)
+	for {
+		var msg struct {
+			Error       string `json:"error"`
+			ErrorDetail struct {
+				Message string `json:"message"`
+			} `json:"errorDetail"`
+		}
+		if err := decoder.Decode(&msg); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return fmt.Errorf("decode stream: %w", err)
+		}
+		if msg.Error != "" {
+			return fmt.Errorf("%s", msg.Error)
+		}
+	}
+}
+
+func envMap(environ []string) map[string]string {
+	out := make(map[string]string, len(environ))
+	for _, kv := range environ {
+		for i := range kv {
+			if kv[i] == '=' {
+				out[kv[:i]] = kv[i+1:]
+				break
+			}
+		}
+	}
+	return out
+}
+
+func envSlice(env composetypes.MappingWithEquals) []string {
+	out := make([]string, 0, len(env))
+	for k, v := range env {
+		if v == nil {
+			out = append(out, k)
+			continue
+		}
+		out = append(out, fmt.Sprintf("%s=%s", k, *v))
+	}
+	return out
+}
+
+func portBindings(ports []composetypes.ServicePortConfig) nat.PortMap {
+	bindings := nat.PortMap{}
+	for _, p := range ports {
+		proto := p.Protocol
+		if proto == "" {
+			proto = "tcp"
+		}
+		containerPort, err := nat.NewPort(proto, fmt.Sprintf("%d", p.Target))
+		if err != nil {
+			continue
+		}
+		bindings[containerPort] = []nat.PortBinding{{HostPort: fmt.Sprintf("%d", p.Published)}}
+	}
+	return bindings
+}
+
+func bindMounts(volumes []composetypes.ServiceVolumeConfig) []string {
+	var binds []string
+	for _, v := range volumes {
+		if v.Type != "bind" || v.Source == "" {
+			continue
+		}
+		binds = append(binds, fmt.Sprintf("%s:%s", v.Source, v.Target))
+	}
+	return binds
+}
+
+// tarBuildContext packages a build context directory into a tar stream for
+// ImageBuild, the way the Docker CLI does before POSTing /build.
+func tarBuildContext(dir string) (io.Reader, error) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	err := filepath.Walk(dir, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if fi.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		header, err := tar.FileInfoHeader(fi, "")
+		if err != nil {
+			return err
+		}
+		header.Name = rel
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	return &buf, nil
+}
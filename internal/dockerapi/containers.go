@@ -0,0 +1,255 @@
+package dockerapi
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// ContainerInfo is the subset of a container's Engine API inspect result the
+// TUI renders, replacing the old `docker ps --format '{{.Status}}'` string
+// parsing with the real structured fields.
+type ContainerInfo struct {
+	ID       string
+	Status   string // container.State.Status: "running", "exited", ...
+	Health   string // "healthy" / "unhealthy" / "running" (no healthcheck) / "unknown"
+	Uptime   string
+	ExitCode int
+	Pid      int // container.State.Pid in the host PID namespace; 0 if not running
+}
+
+// Inspect looks up a container by name and returns its current info. The
+// bool return is false if no container with that name exists.
+func Inspect(ctx context.Context, name string) (ContainerInfo, bool, error) {
+	cli, err := Client()
+	if err != nil {
+		return ContainerInfo{}, false, fmt.Errorf("dockerapi: %w", err)
+	}
+
+	f := filters.NewArgs()
+	f.Add("name", "^/"+name+"$")
+	containers, err := cli.ContainerList(ctx, types.ContainerListOptions{All: true, Filters: f})
+	if err != nil {
+		return ContainerInfo{}, false, fmt.Errorf("dockerapi: list %s: %w", name, err)
+	}
+	if len(containers) == 0 {
+		return ContainerInfo{}, false, nil
+	}
+
+	detail, err := cli.ContainerInspect(ctx, containers[0].ID)
+	if err != nil {
+		return ContainerInfo{}, false, fmt.Errorf("dockerapi: inspect %s: %w", name, err)
+	}
+
+	info := ContainerInfo{ID: shortID(detail.ID), Status: detail.State.Status}
+	switch {
+	case detail.State.Health != nil:
+		info.Health = detail.State.Health.Status
+	case detail.State.Running:
+		info.Health = "running"
+	default:
+		info.Health = "unknown"
+		info.ExitCode = detail.State.ExitCode
+	}
+
+	if detail.State.Running {
+		info.Pid = detail.State.Pid
+		if startedAt, err := time.Parse(time.RFC3339Nano, detail.State.StartedAt); err == nil {
+			info.Uptime = time.Since(startedAt).Round(time.Second).String()
+		}
+	}
+
+	return info, true, nil
+}
+
+// IsRunning reports whether a container with the given name is currently
+// running.
+func IsRunning(ctx context.Context, name string) bool {
+	info, ok, err := Inspect(ctx, name)
+	return err == nil && ok && info.Status == "running"
+}
+
+// Logs returns the last n lines of a container's combined stdout/stderr via
+// ContainerLogs, replacing `docker logs --tail`.
+func Logs(ctx context.Context, name string, tail int) ([]string, error) {
+	cli, err := Client()
+	if err != nil {
+		return nil, fmt.Errorf("dockerapi: %w", err)
+	}
+
+	info, ok, err := Inspect(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("dockerapi: container %s not found", name)
+	}
+
+	reader, err := cli.ContainerLogs(ctx, info.ID, types.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Tail:       fmt.Sprintf("%d", tail),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("dockerapi: logs %s: %w", name, err)
+	}
+	defer reader.Close()
+
+	var stdout, stderr bytes.Buffer
+	if _, err := stdcopy.StdCopy(&stdout, &stderr, reader); err != nil {
+		return nil, fmt.Errorf("dockerapi: demux logs for %s: %w", name, err)
+	}
+
+	combined := strings.TrimRight(stdout.String()+stderr.String(), "\n")
+	if combined == "" {
+		return nil, nil
+	}
+	return strings.Split(combined, "\n"), nil
+}
+
+// StreamLogs returns a live, following reader over a container's combined
+// stdout/stderr, starting tail lines back from the current end. The
+// returned reader is still multiplexed the way ContainerLogs always is;
+// callers demux it with stdcopy.StdCopy. Callers must Close it to stop
+// following.
+func StreamLogs(ctx context.Context, name string, tail int) (io.ReadCloser, error) {
+	cli, err := Client()
+	if err != nil {
+		return nil, fmt.Errorf("dockerapi: %w", err)
+	}
+
+	info, ok, err := Inspect(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("dockerapi: container %s not found", name)
+	}
+
+	reader, err := cli.ContainerLogs(ctx, info.ID, types.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     true,
+		Timestamps: true,
+		Tail:       fmt.Sprintf("%d", tail),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("dockerapi: stream logs %s: %w", name, err)
+	}
+	return reader, nil
+}
+
+// Stop stops a running container, giving it timeout to shut down
+// gracefully before Docker sends SIGKILL.
+func Stop(ctx context.Context, name string, timeout time.Duration) error {
+	cli, err := Client()
+	if err != nil {
+		return fmt.Errorf("dockerapi: %w", err)
+	}
+	info, ok, err := Inspect(ctx, name)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil
+	}
+	seconds := int(timeout.Seconds())
+	if err := cli.ContainerStop(ctx, info.ID, container.StopOptions{Timeout: &seconds}); err != nil {
+		return fmt.Errorf("dockerapi: stop %s: %w", name, err)
+	}
+	return nil
+}
+
+// Remove removes a stopped container.
+func Remove(ctx context.Context, name string) error {
+	cli, err := Client()
+	if err != nil {
+		return fmt.Errorf("dockerapi: %w", err)
+	}
+	info, ok, err := Inspect(ctx, name)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil
+	}
+	if err := cli.ContainerRemove(ctx, info.ID, types.ContainerRemoveOptions{Force: true}); err != nil {
+		return fmt.Errorf("dockerapi: remove %s: %w", name, err)
+	}
+	return nil
+}
+
+// Restart restarts a container by name.
+func Restart(ctx context.Context, name string, timeout time.Duration) error {
+	cli, err := Client()
+	if err != nil {
+		return fmt.Errorf("dockerapi: %w", err)
+	}
+	info, ok, err := Inspect(ctx, name)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("dockerapi: container %s not found", name)
+	}
+	seconds := int(timeout.Seconds())
+	if err := cli.ContainerRestart(ctx, info.ID, container.StopOptions{Timeout: &seconds}); err != nil {
+		return fmt.Errorf("dockerapi: restart %s: %w", name, err)
+	}
+	return nil
+}
+
+// Exec runs cmd inside the named container and returns an error if it
+// can't be started or exits non-zero, the way an exec probe needs to know
+// pass/fail rather than see the command's output.
+func Exec(ctx context.Context, name string, cmd []string) error {
+	cli, err := Client()
+	if err != nil {
+		return fmt.Errorf("dockerapi: %w", err)
+	}
+	info, ok, err := Inspect(ctx, name)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("dockerapi: container %s not found", name)
+	}
+
+	created, err := cli.ContainerExecCreate(ctx, info.ID, types.ExecConfig{Cmd: cmd})
+	if err != nil {
+		return fmt.Errorf("dockerapi: exec create in %s: %w", name, err)
+	}
+
+	attach, err := cli.ContainerExecAttach(ctx, created.ID, types.ExecStartCheck{})
+	if err != nil {
+		return fmt.Errorf("dockerapi: exec attach in %s: %w", name, err)
+	}
+	defer attach.Close()
+	if _, err := io.Copy(io.Discard, attach.Reader); err != nil {
+		return fmt.Errorf("dockerapi: exec read in %s: %w", name, err)
+	}
+
+	inspect, err := cli.ContainerExecInspect(ctx, created.ID)
+	if err != nil {
+		return fmt.Errorf("dockerapi: exec inspect in %s: %w", name, err)
+	}
+	if inspect.ExitCode != 0 {
+		return fmt.Errorf("dockerapi: exec in %s exited %d", name, inspect.ExitCode)
+	}
+	return nil
+}
+
+func shortID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
@@ -0,0 +1,169 @@
+// Package formatter renders container stats as a table, JSON, or a
+// user-supplied Go text/template string, the same split Docker CLI's own
+// formatter.Format makes for `docker stats --format`. The Stats view and
+// the control panel's headless `--stats --format=...` mode both route
+// through Render so interactive use
+// and machine consumption (log aggregators, a Prometheus textfile
+// collector) share one rendering path.
+package formatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// Format selects a named preset ("table", "json", "json-stream") or, for
+// any other value, is parsed as a Go text/template string applied to each
+// StatsFormatContext in turn (e.g. "{{.Name}}\t{{.CPUPerc}}\t{{.MemPerc}}").
+type Format string
+
+const (
+	TableFormat      Format = "table"
+	JSONFormat       Format = "json"
+	JSONStreamFormat Format = "json-stream"
+)
+
+// StatsFormatContext holds one container's stats, the data a template or
+// preset renders from. The raw fields are exported for completeness; the
+// field accessors below (CPUPerc, MemUsage, NetIO, BlockIO, PIDs) are the
+// ones templates and presets actually call, matching the names Docker
+// CLI's own stats template uses.
+type StatsFormatContext struct {
+	Name        string
+	CPUPercent  float64
+	MemPercent  float64
+	MemUsageStr string
+	NetRx       uint64
+	NetTx       uint64
+	BlockRead   uint64
+	BlockWrite  uint64
+	Pids        uint64
+	Status      string
+}
+
+// CPUPerc renders the container's CPU% the way docker stats's CPUPerc
+// column does.
+func (c StatsFormatContext) CPUPerc() string { return fmt.Sprintf("%.2f%%", c.CPUPercent) }
+
+// MemPerc renders the container's memory usage as a percentage of its
+// limit.
+func (c StatsFormatContext) MemPerc() string { return fmt.Sprintf("%.2f%%", c.MemPercent) }
+
+// MemUsage renders "used / limit", already formatted by the caller.
+func (c StatsFormatContext) MemUsage() string { return c.MemUsageStr }
+
+// NetIO renders received/transmitted bytes across every network interface.
+func (c StatsFormatContext) NetIO() string {
+	return fmt.Sprintf("%s / %s", humanBytes(c.NetRx), humanBytes(c.NetTx))
+}
+
+// BlockIO renders bytes read/written across every block device.
+func (c StatsFormatContext) BlockIO() string {
+	return fmt.Sprintf("%s / %s", humanBytes(c.BlockRead), humanBytes(c.BlockWrite))
+}
+
+// PIDs renders the container's current process count.
+func (c StatsFormatContext) PIDs() string { return fmt.Sprintf("%d", c.Pids) }
+
+// jsonRecord is the field set "json" and "json-stream" marshal.
+type jsonRecord struct {
+	Name     string `json:"name"`
+	CPUPerc  string `json:"cpu_perc"`
+	MemUsage string `json:"mem_usage"`
+	MemPerc  string `json:"mem_perc"`
+	NetIO    string `json:"net_io"`
+	BlockIO  string `json:"block_io"`
+	PIDs     string `json:"pids"`
+	Status   string `json:"status"`
+}
+
+func (c StatsFormatContext) toRecord() jsonRecord {
+	return jsonRecord{
+		Name:     c.Name,
+		CPUPerc:  c.CPUPerc(),
+		MemUsage: c.MemUsage(),
+		MemPerc:  c.MemPerc(),
+		NetIO:    c.NetIO(),
+		BlockIO:  c.BlockIO(),
+		PIDs:     c.PIDs(),
+		Status:   c.Status,
+	}
+}
+
+// Render formats contexts per format. "table" (and "") renders one aligned
+// line per container; "json" marshals the whole slice as one array;
+// "json-stream" marshals one NDJSON object per container, for feeding a
+// log aggregator or a Prometheus textfile collector one record per tick;
+// anything else is parsed and executed as a Go text/template against each
+// context.
+func Render(format Format, contexts []StatsFormatContext) (string, error) {
+	switch format {
+	case TableFormat, "":
+		return renderTable(contexts), nil
+	case JSONFormat:
+		records := make([]jsonRecord, len(contexts))
+		for i, c := range contexts {
+			records[i] = c.toRecord()
+		}
+		data, err := json.MarshalIndent(records, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("formatter: marshal json: %w", err)
+		}
+		return string(data), nil
+	case JSONStreamFormat:
+		var b strings.Builder
+		for _, c := range contexts {
+			data, err := json.Marshal(c.toRecord())
+			if err != nil {
+				return "", fmt.Errorf("formatter: marshal json-stream record: %w", err)
+			}
+			b.Write(data)
+			b.WriteByte('\n')
+		}
+		return b.String(), nil
+	default:
+		return renderTemplate(string(format), contexts)
+	}
+}
+
+func renderTable(contexts []StatsFormatContext) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-20s %10s %10s %20s %20s %20s %6s\n", "NAME", "CPU %", "MEM %", "MEM USAGE", "NET I/O", "BLOCK I/O", "PIDS")
+	for _, c := range contexts {
+		fmt.Fprintf(&b, "%-20s %10s %10s %20s %20s %20s %6s\n",
+			c.Name, c.CPUPerc(), c.MemPerc(), c.MemUsage(), c.NetIO(), c.BlockIO(), c.PIDs())
+	}
+	return b.String()
+}
+
+func renderTemplate(tmplStr string, contexts []StatsFormatContext) (string, error) {
+	tmpl, err := template.New("stats").Parse(tmplStr)
+	if err != nil {
+		return "", fmt.Errorf("formatter: parse template: %w", err)
+	}
+	var b strings.Builder
+	for _, c := range contexts {
+		if err := tmpl.Execute(&b, c); err != nil {
+			return "", fmt.Errorf("formatter: execute template: %w", err)
+		}
+		b.WriteByte('\n')
+	}
+	return b.String(), nil
+}
+
+// humanBytes renders n as a short binary-unit string, matching Docker's
+// own MemUsage/NetIO/BlockIO columns.
+func humanBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := uint64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,148 @@
+// Package graphstats queries the FalkorDB container directly over the
+// redis protocol for live knowledge-graph statistics — node/edge counts,
+// memory usage, and the slow-query log — replacing updateGraphStats's
+// hardcoded zero-value stub. It degrades gracefully when FalkorDB is
+// unreachable, mirroring how fetchLogs handles a missing container,
+// rather than blocking the tick loop.
+package graphstats
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const graphName = "atlas"
+
+// SlowQuery is one entry from FalkorDB's GRAPH.SLOWLOG.
+type SlowQuery struct {
+	Timestamp string
+	Command   string
+	Query     string
+	Duration  string
+}
+
+// Stats is one sample of the atlas graph's live statistics.
+type Stats struct {
+	NodeCount   int
+	EdgeCount   int
+	MemoryUsage string
+	SlowQueries []SlowQuery
+	LastUpdate  string
+}
+
+// Collector holds a pooled redis connection to FalkorDB, reused across
+// Collect calls the way dockerapi.Client() caches a single Docker client.
+type Collector struct {
+	rdb *redis.Client
+}
+
+// NewCollector opens a pooled connection to addr, FalkorDB's redis-protocol
+// host:port.
+func NewCollector(addr string) *Collector {
+	return &Collector{rdb: redis.NewClient(&redis.Options{Addr: addr})}
+}
+
+// Close releases the pooled connection.
+func (c *Collector) Close() error { return c.rdb.Close() }
+
+// Collect issues GRAPH.QUERY for node/edge counts plus GRAPH.MEMORY USAGE
+// and GRAPH.SLOWLOG against the atlas graph, and LASTSAVE for the
+// last-update timestamp.
+func (c *Collector) Collect(ctx context.Context) (Stats, error) {
+	nodeCount, err := c.queryCount(ctx, "MATCH (n) RETURN count(n)")
+	if err != nil {
+		return Stats{}, fmt.Errorf("graphstats: query node count: %w", err)
+	}
+	edgeCount, err := c.queryCount(ctx, "MATCH ()-[r]->() RETURN count(r)")
+	if err != nil {
+		return Stats{}, fmt.Errorf("graphstats: query edge count: %w", err)
+	}
+
+	memUsage := "unknown"
+	if v, err := c.rdb.Do(ctx, "GRAPH.MEMORY", "USAGE", graphName).Result(); err == nil {
+		memUsage = fmt.Sprintf("%v", v)
+	}
+
+	var slowQueries []SlowQuery
+	if v, err := c.rdb.Do(ctx, "GRAPH.SLOWLOG", graphName).Result(); err == nil {
+		slowQueries = parseSlowLog(v)
+	}
+
+	lastUpdate := "unknown"
+	if v, err := c.rdb.Do(ctx, "LASTSAVE").Result(); err == nil {
+		if secs, ok := v.(int64); ok {
+			lastUpdate = time.Unix(secs, 0).Format("15:04:05")
+		}
+	}
+
+	return Stats{
+		NodeCount:   nodeCount,
+		EdgeCount:   edgeCount,
+		MemoryUsage: memUsage,
+		SlowQueries: slowQueries,
+		LastUpdate:  lastUpdate,
+	}, nil
+}
+
+// queryCount issues query against the atlas graph and reads the single
+// scalar column of its single result row — the shape `RETURN count(...)`
+// always returns.
+func (c *Collector) queryCount(ctx context.Context, query string) (int, error) {
+	res, err := c.rdb.Do(ctx, "GRAPH.QUERY", graphName, query).Result()
+	if err != nil {
+		return 0, err
+	}
+	// A GRAPH.QUERY reply is [header, rows, stats]; rows is a slice of
+	// one slice per result row.
+	reply, ok := res.([]interface{})
+	if !ok || len(reply) < 2 {
+		return 0, fmt.Errorf("unexpected GRAPH.QUERY reply shape")
+	}
+	rows, ok := reply[1].([]interface{})
+	if !ok || len(rows) == 0 {
+		return 0, nil
+	}
+	row, ok := rows[0].([]interface{})
+	if !ok || len(row) == 0 {
+		return 0, nil
+	}
+	return toInt(row[0])
+}
+
+func toInt(v interface{}) (int, error) {
+	switch n := v.(type) {
+	case int64:
+		return int(n), nil
+	case string:
+		return strconv.Atoi(n)
+	default:
+		return 0, fmt.Errorf("graphstats: unexpected count type %T", v)
+	}
+}
+
+// parseSlowLog converts a GRAPH.SLOWLOG reply (one array of
+// [timestamp, command, query, duration-ms] per entry) into SlowQuerys.
+func parseSlowLog(v interface{}) []SlowQuery {
+	entries, ok := v.([]interface{})
+	if !ok {
+		return nil
+	}
+	var out []SlowQuery
+	for _, e := range entries {
+		fields, ok := e.([]interface{})
+		if !ok || len(fields) < 4 {
+			continue
+		}
+		out = append(out, SlowQuery{
+			Timestamp: fmt.Sprintf("%v", fields[0]),
+			Command:   fmt.Sprintf("%v", fields[1]),
+			Query:     fmt.Sprintf("%v", fields[2]),
+			Duration:  fmt.Sprintf("%v", fields[3]),
+		})
+	}
+	return out
+}
@@ -0,0 +1,112 @@
+// Package logstream follows a container's combined stdout/stderr log
+// stream continuously, replacing the one-shot `docker logs --tail` poll
+// fetchLogs used to re-run every tick. A background goroutine demultiplexes
+// the 8-byte stdcopy frame header Docker's log stream uses into separate
+// stdout/stderr readers and appends each line, tagged with an inferred
+// severity, to a fixed-size per-service ring buffer the Logs view reads
+// from directly.
+package logstream
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/pkg/stdcopy"
+
+	"github.com/KumarVandit/data_hackathon/internal/dockerapi"
+)
+
+// DefaultCapacity is the ring buffer size Follow's callers use absent a
+// more specific requirement.
+const DefaultCapacity = 2000
+
+// Line is one log line plus its inferred severity.
+type Line struct {
+	Text  string
+	Level string // "ERROR", "WARN", "INFO", "DEBUG", or "" if unrecognized
+}
+
+// RingBuffer is a fixed-capacity, concurrency-safe buffer of the most
+// recent log lines; once full, each Push evicts the oldest line.
+type RingBuffer struct {
+	mu       sync.Mutex
+	lines    []Line
+	capacity int
+}
+
+// NewRingBuffer returns an empty RingBuffer holding at most capacity lines.
+func NewRingBuffer(capacity int) *RingBuffer {
+	return &RingBuffer{capacity: capacity}
+}
+
+// Push appends line, evicting the oldest line first if the buffer is full.
+func (b *RingBuffer) Push(line Line) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.lines = append(b.lines, line)
+	if len(b.lines) > b.capacity {
+		b.lines = b.lines[len(b.lines)-b.capacity:]
+	}
+}
+
+// Lines returns a snapshot of the buffer's current contents, oldest first.
+func (b *RingBuffer) Lines() []Line {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	out := make([]Line, len(b.lines))
+	copy(out, b.lines)
+	return out
+}
+
+// Follow opens a following log stream for containerName and starts
+// demuxing it into buf in background goroutines; it returns once the
+// stream is open, not once it ends. The goroutines exit once ctx is
+// cancelled or the stream errors out.
+func Follow(ctx context.Context, containerName string, buf *RingBuffer) error {
+	reader, err := dockerapi.StreamLogs(ctx, containerName, 200)
+	if err != nil {
+		return err
+	}
+
+	stdoutR, stdoutW := io.Pipe()
+	stderrR, stderrW := io.Pipe()
+
+	go func() {
+		<-ctx.Done()
+		reader.Close()
+	}()
+
+	go func() {
+		defer stdoutW.Close()
+		defer stderrW.Close()
+		stdcopy.StdCopy(stdoutW, stderrW, reader)
+	}()
+
+	go scanInto(stdoutR, buf)
+	go scanInto(stderrR, buf)
+	return nil
+}
+
+func scanInto(r io.Reader, buf *RingBuffer) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		text := scanner.Text()
+		buf.Push(Line{Text: text, Level: inferLevel(text)})
+	}
+}
+
+// inferLevel looks for a common level token in text, the way most
+// application logs tag their own severity somewhere in the line.
+func inferLevel(text string) string {
+	upper := strings.ToUpper(text)
+	for _, level := range []string{"ERROR", "WARN", "INFO", "DEBUG"} {
+		if strings.Contains(upper, level) {
+			return level
+		}
+	}
+	return ""
+}
@@ -0,0 +1,110 @@
+// Package metrics exposes the same host/container/graph samples the TUI
+// already collects each tick as a Prometheus /metrics endpoint, the way the
+// gopsutil metrics example registers one gauge per sampled value against a
+// shared registry rather than standing up a second, independently-polling
+// collector. updateStats, the containerStatMsg handler, and
+// updateGraphStats call the Set* functions here as a side effect of the
+// sampling they already do, so --metrics-addr and the TUI read off a single
+// sampling path.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	registry = prometheus.NewRegistry()
+
+	hostCPUPercent = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "atlas_host_cpu_percent",
+		Help: "Host CPU utilization percentage.",
+	})
+	hostMemUsedBytes = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "atlas_host_mem_used_bytes",
+		Help: "Host memory currently in use, in bytes.",
+	})
+	hostDiskUsedBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "atlas_host_disk_used_bytes",
+		Help: "Host disk space currently in use, in bytes, by mount point.",
+	}, []string{"mount"})
+
+	containerCPUPercent = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "atlas_container_cpu_percent",
+		Help: "Container CPU utilization percentage.",
+	}, []string{"name"})
+	containerMemPercent = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "atlas_container_mem_percent",
+		Help: "Container memory usage as a percentage of its limit.",
+	}, []string{"name"})
+	containerMemUsageBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "atlas_container_mem_usage_bytes",
+		Help: "Container memory usage, in bytes.",
+	}, []string{"name"})
+
+	graphNodes = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "atlas_graph_nodes",
+		Help: "Node count of the atlas FalkorDB graph.",
+	})
+	graphEdges = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "atlas_graph_edges",
+		Help: "Edge count of the atlas FalkorDB graph.",
+	})
+)
+
+func init() {
+	registry.MustRegister(
+		hostCPUPercent,
+		hostMemUsedBytes,
+		hostDiskUsedBytes,
+		containerCPUPercent,
+		containerMemPercent,
+		containerMemUsageBytes,
+		graphNodes,
+		graphEdges,
+	)
+}
+
+// SetHostStats records the latest host-level CPU/memory/disk sample.
+func SetHostStats(cpuPercent float64, memUsedBytes uint64, diskMount string, diskUsedBytes uint64) {
+	hostCPUPercent.Set(cpuPercent)
+	hostMemUsedBytes.Set(float64(memUsedBytes))
+	hostDiskUsedBytes.WithLabelValues(diskMount).Set(float64(diskUsedBytes))
+}
+
+// SetContainerStats records the latest sample for one container, keyed by
+// its service/container name.
+func SetContainerStats(name string, cpuPercent, memPercent float64, memUsageBytes uint64) {
+	containerCPUPercent.WithLabelValues(name).Set(cpuPercent)
+	containerMemPercent.WithLabelValues(name).Set(memPercent)
+	containerMemUsageBytes.WithLabelValues(name).Set(float64(memUsageBytes))
+}
+
+// DeleteContainer drops a container's gauges once its stats stream ends, so
+// a stopped or removed container doesn't linger in scrapes forever.
+func DeleteContainer(name string) {
+	containerCPUPercent.DeleteLabelValues(name)
+	containerMemPercent.DeleteLabelValues(name)
+	containerMemUsageBytes.DeleteLabelValues(name)
+}
+
+// SetGraphStats records the latest FalkorDB node/edge counts.
+func SetGraphStats(nodeCount, edgeCount int) {
+	graphNodes.Set(float64(nodeCount))
+	graphEdges.Set(float64(edgeCount))
+}
+
+// Handler returns the /metrics HTTP handler for the shared registry.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// Serve starts an HTTP server on addr exposing /metrics and blocks until it
+// exits or errors; callers run it in its own goroutine.
+func Serve(addr string) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler())
+	return http.ListenAndServe(addr, mux)
+}
@@ -0,0 +1,28 @@
+package otlpsink
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+type fileConfig struct {
+	Enabled  bool   `yaml:"enabled"`
+	GRPCAddr string `yaml:"grpc_addr"`
+	HTTPAddr string `yaml:"http_addr"`
+}
+
+// LoadConfig reads config/otlp.yaml. A missing or unreadable file is
+// returned as an error; callers fall back to DefaultConfig on any error.
+func LoadConfig(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("read otlp config: %w", err)
+	}
+	var fc fileConfig
+	if err := yaml.Unmarshal(data, &fc); err != nil {
+		return Config{}, fmt.Errorf("parse otlp config: %w", err)
+	}
+	return Config{Enabled: fc.Enabled, GRPCAddr: fc.GRPCAddr, HTTPAddr: fc.HTTPAddr}, nil
+}
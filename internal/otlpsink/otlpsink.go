@@ -0,0 +1,201 @@
+// Package otlpsink embeds a minimal OTLP log/trace receiver (gRPC on 4317,
+// HTTP on 4318) inside the control panel process, mirroring the collector
+// pattern tracetest's agent uses: services configured with
+// OTEL_EXPORTER_OTLP_ENDPOINT=http://localhost:4317 can push structured
+// logs and spans straight into the TUI instead of it shelling out to
+// `docker logs`.
+package otlpsink
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+)
+
+// Config controls whether the receiver runs at all, and which addresses it
+// binds. Disabling it lets users on constrained machines fall back to the
+// existing `docker logs` scraper.
+type Config struct {
+	Enabled  bool
+	GRPCAddr string // default ":4317"
+	HTTPAddr string // default ":4318"
+}
+
+// DefaultConfig is the receiver's out-of-the-box configuration.
+func DefaultConfig() Config {
+	return Config{Enabled: true, GRPCAddr: ":4317", HTTPAddr: ":4318"}
+}
+
+// LogEvent is one received LogRecord, trimmed down to what the TUI's Logs
+// view renders.
+type LogEvent struct {
+	Service   string
+	Timestamp time.Time
+	Severity  string
+	Body      string
+}
+
+// SpanEvent is one received Span, trimmed down to what the Traces view's
+// waterfall renders.
+type SpanEvent struct {
+	Service string
+	Name    string
+	Start   time.Time
+	End     time.Time
+	Status  string
+}
+
+// Start binds the gRPC and HTTP listeners and begins serving, pushing
+// decoded events onto logs and spans as they arrive. It returns once both
+// listeners are bound; serving continues in background goroutines until ctx
+// is cancelled. A disabled Config is a no-op.
+func Start(ctx context.Context, cfg Config, logs chan<- LogEvent, spans chan<- SpanEvent) error {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	sink := &sink{logs: logs, spans: spans}
+
+	lis, err := net.Listen("tcp", cfg.GRPCAddr)
+	if err != nil {
+		return fmt.Errorf("otlpsink: listen grpc %s: %w", cfg.GRPCAddr, err)
+	}
+	grpcServer := grpc.NewServer()
+	collogspb.RegisterLogsServiceServer(grpcServer, &logsServer{sink: sink})
+	coltracepb.RegisterTraceServiceServer(grpcServer, &tracesServer{sink: sink})
+	go grpcServer.Serve(lis)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/logs", sink.handleHTTPLogs)
+	mux.HandleFunc("/v1/traces", sink.handleHTTPTraces)
+	httpLis, err := net.Listen("tcp", cfg.HTTPAddr)
+	if err != nil {
+		grpcServer.Stop()
+		return fmt.Errorf("otlpsink: listen http %s: %w", cfg.HTTPAddr, err)
+	}
+	httpServer := &http.Server{Handler: mux}
+	go httpServer.Serve(httpLis)
+
+	go func() {
+		<-ctx.Done()
+		grpcServer.GracefulStop()
+		httpServer.Close()
+	}()
+
+	return nil
+}
+
+// sink decodes OTLP protobuf messages (shared by the gRPC and HTTP/protobuf
+// receivers) into LogEvent/SpanEvent and forwards them to the model's
+// channels.
+type sink struct {
+	logs  chan<- LogEvent
+	spans chan<- SpanEvent
+}
+
+func resourceServiceName(attrs []*commonpb.KeyValue) string {
+	for _, kv := range attrs {
+		if kv.GetKey() == "service.name" {
+			return kv.GetValue().GetStringValue()
+		}
+	}
+	return "unknown"
+}
+
+func (s *sink) exportLogs(req *collogspb.ExportLogsServiceRequest) {
+	for _, rl := range req.GetResourceLogs() {
+		service := resourceServiceName(rl.GetResource().GetAttributes())
+		for _, sl := range rl.GetScopeLogs() {
+			for _, record := range sl.GetLogRecords() {
+				s.logs <- LogEvent{
+					Service:   service,
+					Timestamp: time.Unix(0, int64(record.GetTimeUnixNano())),
+					Severity:  record.GetSeverityText(),
+					Body:      record.GetBody().GetStringValue(),
+				}
+			}
+		}
+	}
+}
+
+func (s *sink) exportSpans(req *coltracepb.ExportTraceServiceRequest) {
+	for _, rs := range req.GetResourceSpans() {
+		service := resourceServiceName(rs.GetResource().GetAttributes())
+		for _, ss := range rs.GetScopeSpans() {
+			for _, span := range ss.GetSpans() {
+				s.spans <- SpanEvent{
+					Service: service,
+					Name:    span.GetName(),
+					Start:   time.Unix(0, int64(span.GetStartTimeUnixNano())),
+					End:     time.Unix(0, int64(span.GetEndTimeUnixNano())),
+					Status:  span.GetStatus().GetMessage(),
+				}
+			}
+		}
+	}
+}
+
+// logsServer implements the OTLP LogsService gRPC interface.
+type logsServer struct {
+	collogspb.UnimplementedLogsServiceServer
+	sink *sink
+}
+
+func (l *logsServer) Export(ctx context.Context, req *collogspb.ExportLogsServiceRequest) (*collogspb.ExportLogsServiceResponse, error) {
+	l.sink.exportLogs(req)
+	return &collogspb.ExportLogsServiceResponse{}, nil
+}
+
+// tracesServer implements the OTLP TraceService gRPC interface.
+type tracesServer struct {
+	coltracepb.UnimplementedTraceServiceServer
+	sink *sink
+}
+
+func (t *tracesServer) Export(ctx context.Context, req *coltracepb.ExportTraceServiceRequest) (*coltracepb.ExportTraceServiceResponse, error) {
+	t.sink.exportSpans(req)
+	return &coltracepb.ExportTraceServiceResponse{}, nil
+}
+
+// handleHTTPLogs accepts OTLP/HTTP logs (protobuf-encoded, the default
+// content type for SDKs that don't opt into JSON).
+func (s *sink) handleHTTPLogs(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var req collogspb.ExportLogsServiceRequest
+	if err := proto.Unmarshal(body, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.exportLogs(&req)
+	w.WriteHeader(http.StatusOK)
+}
+
+// handleHTTPTraces accepts OTLP/HTTP traces (protobuf-encoded).
+func (s *sink) handleHTTPTraces(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var req coltracepb.ExportTraceServiceRequest
+	if err := proto.Unmarshal(body, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.exportSpans(&req)
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,100 @@
+package probe
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// fileProbe is the YAML shape of a single Probe; exactly one of Cmd, URL,
+// or Addr should be set, matching which probe mechanism it describes.
+type fileProbe struct {
+	Cmd          []string `yaml:"cmd,omitempty"`
+	URL          string   `yaml:"url,omitempty"`
+	ExpectStatus int      `yaml:"expect_status,omitempty"`
+	Addr         string   `yaml:"addr,omitempty"`
+}
+
+type fileSpec struct {
+	Probes              []fileProbe `yaml:"probes"`
+	InitialDelaySeconds int         `yaml:"initial_delay_seconds"`
+	PeriodSeconds       int         `yaml:"period_seconds"`
+	FailureThreshold    int         `yaml:"failure_threshold"`
+	SuccessThreshold    int         `yaml:"success_threshold"`
+}
+
+type fileConfig struct {
+	Services map[string]fileSpec `yaml:"services"`
+}
+
+// Config maps a service name (the Docker container name, e.g. "falkordb")
+// to the probe Spec that decides when it's ready.
+type Config struct {
+	Services map[string]Spec
+}
+
+// LoadConfig reads a probes.yaml, letting operators override or add probes
+// per environment without touching Go code.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("probe: read %s: %w", path, err)
+	}
+	var fc fileConfig
+	if err := yaml.Unmarshal(data, &fc); err != nil {
+		return nil, fmt.Errorf("probe: parse %s: %w", path, err)
+	}
+
+	cfg := &Config{Services: make(map[string]Spec, len(fc.Services))}
+	for name, fs := range fc.Services {
+		spec := Spec{
+			InitialDelaySeconds: fs.InitialDelaySeconds,
+			PeriodSeconds:       fs.PeriodSeconds,
+			FailureThreshold:    fs.FailureThreshold,
+			SuccessThreshold:    fs.SuccessThreshold,
+		}
+		for _, fp := range fs.Probes {
+			switch {
+			case len(fp.Cmd) > 0:
+				spec.Probes = append(spec.Probes, Probe{Exec: &ExecProbe{Cmd: fp.Cmd}})
+			case fp.URL != "":
+				spec.Probes = append(spec.Probes, Probe{HTTP: &HTTPProbe{URL: fp.URL, ExpectStatus: fp.ExpectStatus}})
+			case fp.Addr != "":
+				spec.Probes = append(spec.Probes, Probe{TCP: &TCPProbe{Addr: fp.Addr}})
+			}
+		}
+		cfg.Services[name] = spec
+	}
+	return cfg, nil
+}
+
+// DefaultConfig returns the probe set the control panel ships with: a
+// Redis-protocol TCP dial for FalkorDB, an /api/tags check for Ollama, and
+// an HTTP /health check for the Atlas dashboard. Atlas Engine and Graphiti
+// MCP have no probe here, so runProbes falls back to Docker's health field.
+func DefaultConfig() *Config {
+	return &Config{Services: map[string]Spec{
+		"falkordb": {
+			Probes:              []Probe{{TCP: &TCPProbe{Addr: "localhost:6379"}}},
+			InitialDelaySeconds: 2,
+			PeriodSeconds:       3,
+			FailureThreshold:    10,
+			SuccessThreshold:    1,
+		},
+		"ollama": {
+			Probes:              []Probe{{HTTP: &HTTPProbe{URL: "http://localhost:11434/api/tags", ExpectStatus: 200}}},
+			InitialDelaySeconds: 5,
+			PeriodSeconds:       3,
+			FailureThreshold:    30,
+			SuccessThreshold:    1,
+		},
+		"atlas-dashboard": {
+			Probes:              []Probe{{HTTP: &HTTPProbe{URL: "http://localhost:5173/health", ExpectStatus: 200}}},
+			InitialDelaySeconds: 3,
+			PeriodSeconds:       3,
+			FailureThreshold:    10,
+			SuccessThreshold:    1,
+		},
+	}}
+}
@@ -0,0 +1,111 @@
+// Package probe implements Kubernetes-style exec/HTTP/TCP probes for the
+// services the control panel manages. Docker's own HEALTHCHECK field isn't
+// populated uniformly across our containers (FalkorDB and Ollama don't
+// declare one at all), so this package lets each service describe how to
+// tell it's actually ready: a Redis-protocol TCP dial for FalkorDB, an
+// `/api/tags` HTTP check for Ollama, a `docker exec` for anything that
+// needs an in-container check.
+package probe
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/KumarVandit/data_hackathon/internal/dockerapi"
+)
+
+// Probe is a tagged union of the three probe mechanisms Kubernetes
+// supports. Exactly one of Exec, HTTP, or TCP should be set.
+type Probe struct {
+	Exec *ExecProbe
+	HTTP *HTTPProbe
+	TCP  *TCPProbe
+}
+
+// ExecProbe passes if Cmd exits zero inside the target container.
+type ExecProbe struct {
+	Cmd []string
+}
+
+// HTTPProbe passes if a GET to URL returns ExpectStatus.
+type HTTPProbe struct {
+	URL          string
+	ExpectStatus int
+}
+
+// TCPProbe passes if a TCP connection to Addr succeeds.
+type TCPProbe struct {
+	Addr string
+}
+
+// Spec mirrors a Kubernetes probe's shape: one or more probes that must all
+// pass, plus the timing knobs a caller's polling loop uses to decide when a
+// service is ready.
+type Spec struct {
+	Probes              []Probe
+	InitialDelaySeconds int
+	PeriodSeconds       int
+	FailureThreshold    int
+	SuccessThreshold    int
+}
+
+// CheckOnce runs every probe in the spec once against containerName and
+// reports whether they all passed. Callers run this on each tick of their
+// own polling loop and track SuccessThreshold/FailureThreshold consecutive
+// results themselves, the way a kubelet does.
+func (s Spec) CheckOnce(ctx context.Context, containerName string) bool {
+	for _, p := range s.Probes {
+		pctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+		err := p.check(pctx, containerName)
+		cancel()
+		if err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func (p Probe) check(ctx context.Context, containerName string) error {
+	switch {
+	case p.Exec != nil:
+		return dockerapi.Exec(ctx, containerName, p.Exec.Cmd)
+	case p.HTTP != nil:
+		return checkHTTP(ctx, p.HTTP)
+	case p.TCP != nil:
+		return checkTCP(ctx, p.TCP)
+	default:
+		return fmt.Errorf("probe: no probe mechanism configured")
+	}
+}
+
+func checkHTTP(ctx context.Context, p *HTTPProbe) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.URL, nil)
+	if err != nil {
+		return fmt.Errorf("probe: build request for %s: %w", p.URL, err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("probe: GET %s: %w", p.URL, err)
+	}
+	defer resp.Body.Close()
+	want := p.ExpectStatus
+	if want == 0 {
+		want = http.StatusOK
+	}
+	if resp.StatusCode != want {
+		return fmt.Errorf("probe: %s returned %d, want %d", p.URL, resp.StatusCode, want)
+	}
+	return nil
+}
+
+func checkTCP(ctx context.Context, p *TCPProbe) error {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", p.Addr)
+	if err != nil {
+		return fmt.Errorf("probe: dial %s: %w", p.Addr, err)
+	}
+	return conn.Close()
+}
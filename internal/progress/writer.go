@@ -0,0 +1,204 @@
+// Package progress renders BuildKit/Dagger-style vertex progress: each
+// long-running action (starting a service, building an image, ...) is a
+// vertex with a stable ID, a header line, and a rolling window of its
+// recent log lines that collapses to a one-line summary once it finishes.
+package progress
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// State is the lifecycle state of a vertex.
+type State int
+
+const (
+	StatePending State = iota
+	StateRunning
+	StateDone
+	StateError
+)
+
+// Glyph returns the single-rune status indicator shown next to a vertex.
+func (s State) Glyph() string {
+	switch s {
+	case StatePending:
+		return "○"
+	case StateRunning:
+		return "⟳"
+	case StateDone:
+		return "✓"
+	case StateError:
+		return "✗"
+	default:
+		return "?"
+	}
+}
+
+func (s State) String() string {
+	switch s {
+	case StatePending:
+		return "pending"
+	case StateRunning:
+		return "running"
+	case StateDone:
+		return "done"
+	case StateError:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+const logWindow = 10
+
+// vertex is a single unit of progress tracked by a Writer.
+type vertex struct {
+	id        string
+	name      string
+	state     State
+	startedAt time.Time
+	err       error
+	logs      []string
+}
+
+// Writer tracks a set of vertices and renders them BuildKit-style. In
+// "plain" mode (the --plain fallback for non-TTY environments) every event
+// is written to out immediately as a single line instead of being held for
+// a diffed, in-place redraw by the caller.
+type Writer struct {
+	mu       sync.Mutex
+	out      io.Writer
+	plain    bool
+	order    []string
+	vertices map[string]*vertex
+}
+
+// NewWriter returns a Writer that emits plain one-line-per-event output to
+// out when plain is true, and otherwise just accumulates vertex state for
+// a caller-driven redraw (see Snapshot).
+func NewWriter(out io.Writer, plain bool) *Writer {
+	return &Writer{out: out, plain: plain, vertices: make(map[string]*vertex)}
+}
+
+func (w *Writer) vertexLocked(id string) *vertex {
+	v, ok := w.vertices[id]
+	if !ok {
+		v = &vertex{id: id, state: StatePending}
+		w.vertices[id] = v
+		w.order = append(w.order, id)
+	}
+	return v
+}
+
+// Vertex registers a vertex with the given id and display name, creating
+// it if it doesn't exist yet.
+func (w *Writer) Vertex(id, name string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.vertexLocked(id).name = name
+}
+
+// VertexStatus transitions a vertex to a new state.
+func (w *Writer) VertexStatus(id string, state State) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	v := w.vertexLocked(id)
+	if v.state != StateRunning && state == StateRunning {
+		v.startedAt = time.Now()
+	}
+	v.state = state
+	if w.plain {
+		fmt.Fprintf(w.out, "#%s %s %s\n", id, v.name, state)
+	}
+}
+
+// VertexLog appends a line to the vertex's rolling log window.
+func (w *Writer) VertexLog(id, line string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	v := w.vertexLocked(id)
+	v.logs = append(v.logs, line)
+	if len(v.logs) > logWindow {
+		v.logs = v.logs[len(v.logs)-logWindow:]
+	}
+	if w.plain {
+		fmt.Fprintf(w.out, "#%s %s\n", id, line)
+	}
+}
+
+// VertexDone marks a vertex complete, successfully or not.
+func (w *Writer) VertexDone(id string, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	v := w.vertexLocked(id)
+	v.err = err
+	if err != nil {
+		v.state = StateError
+	} else {
+		v.state = StateDone
+	}
+	if w.plain {
+		if err != nil {
+			fmt.Fprintf(w.out, "#%s %s error: %v\n", id, v.name, err)
+		} else {
+			fmt.Fprintf(w.out, "#%s %s done\n", id, v.name)
+		}
+	}
+}
+
+// Vertex is a read-only snapshot of a tracked vertex, safe to render
+// without holding the Writer's lock.
+type Vertex struct {
+	ID      string
+	Name    string
+	State   State
+	Elapsed time.Duration
+	Err     error
+	Logs    []string
+}
+
+// Snapshot returns the current vertices in registration order, for a
+// caller (e.g. a Bubble Tea View) to diff against its previous frame and
+// redraw only what changed.
+func (w *Writer) Snapshot() []Vertex {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	out := make([]Vertex, 0, len(w.order))
+	for _, id := range w.order {
+		v := w.vertices[id]
+		var elapsed time.Duration
+		if !v.startedAt.IsZero() {
+			elapsed = time.Since(v.startedAt)
+		}
+		logs := make([]string, len(v.logs))
+		copy(logs, v.logs)
+		out = append(out, Vertex{ID: v.id, Name: v.name, State: v.state, Elapsed: elapsed, Err: v.err, Logs: logs})
+	}
+	return out
+}
+
+// Done reports whether every tracked vertex has reached a terminal state.
+func (w *Writer) Done() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.vertices) == 0 {
+		return false
+	}
+	for _, v := range w.vertices {
+		if v.state != StateDone && v.state != StateError {
+			return false
+		}
+	}
+	return true
+}
+
+// Reset clears all tracked vertices, e.g. before starting a new run.
+func (w *Writer) Reset() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.vertices = make(map[string]*vertex)
+	w.order = nil
+}
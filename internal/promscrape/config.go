@@ -0,0 +1,57 @@
+package promscrape
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ServiceConfig describes where to scrape a service's /metrics endpoint and
+// which metric names from that endpoint are worth promoting to the TUI.
+type ServiceConfig struct {
+	URL     string   `yaml:"url"`
+	Metrics []string `yaml:"metrics"`
+}
+
+// Config is the top-level shape of config/metrics.yaml: one ServiceConfig
+// per service display name (matching service.name in main.go).
+type Config struct {
+	Services map[string]ServiceConfig `yaml:"services"`
+}
+
+// LoadConfig reads and parses a metrics.yaml file. A missing or unreadable
+// file is returned as an error; callers fall back to DefaultConfig on any
+// error so the panel still works without a user-supplied config.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read metrics config: %w", err)
+	}
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse metrics config: %w", err)
+	}
+	return &cfg, nil
+}
+
+// DefaultConfig is used when config/metrics.yaml is absent, covering the
+// services that are known to expose Prometheus-format metrics out of the box.
+func DefaultConfig() *Config {
+	return &Config{
+		Services: map[string]ServiceConfig{
+			"Graphiti MCP": {
+				URL:     "http://localhost:8000/metrics",
+				Metrics: []string{"graphiti_llm_requests_total"},
+			},
+			"Atlas Dashboard": {
+				URL:     "http://localhost:8001/metrics",
+				Metrics: []string{"dashboard_requests_total"},
+			},
+			"FalkorDB": {
+				URL:     "http://localhost:9121/metrics",
+				Metrics: []string{"falkordb_commands_processed_total"},
+			},
+		},
+	}
+}
@@ -0,0 +1,79 @@
+package promscrape
+
+const historySize = 120
+
+var sparkGlyphs = []rune("▁▂▃▄▅▆▇█")
+
+// History is a fixed-capacity ring buffer of recent samples for a single
+// metric, used to render an inline sparkline.
+type History struct {
+	values []float64
+	cap    int
+	pos    int
+	filled bool
+}
+
+// NewHistory returns an empty History that holds up to historySize samples.
+func NewHistory() *History {
+	return &History{values: make([]float64, historySize), cap: historySize}
+}
+
+// Push appends the latest value, overwriting the oldest once full.
+func (h *History) Push(value float64) {
+	h.values[h.pos] = value
+	h.pos = (h.pos + 1) % h.cap
+	if h.pos == 0 {
+		h.filled = true
+	}
+}
+
+// Values returns the stored samples in chronological order.
+func (h *History) Values() []float64 {
+	if !h.filled {
+		return append([]float64(nil), h.values[:h.pos]...)
+	}
+	out := make([]float64, 0, h.cap)
+	out = append(out, h.values[h.pos:]...)
+	out = append(out, h.values[:h.pos]...)
+	return out
+}
+
+// Latest returns the most recently pushed value, or 0 if empty.
+func (h *History) Latest() float64 {
+	values := h.Values()
+	if len(values) == 0 {
+		return 0
+	}
+	return values[len(values)-1]
+}
+
+// Sparkline renders the stored history as a string of unicode block
+// glyphs, one per sample, scaled between the window's min and max.
+func (h *History) Sparkline() string {
+	values := h.Values()
+	if len(values) == 0 {
+		return ""
+	}
+
+	min, max := values[0], values[0]
+	for _, v := range values {
+		if v < min {
+			min = v
+		}
+		if v > max {
+			max = v
+		}
+	}
+
+	spread := max - min
+	out := make([]rune, len(values))
+	for i, v := range values {
+		if spread == 0 {
+			out[i] = sparkGlyphs[0]
+			continue
+		}
+		idx := int((v - min) / spread * float64(len(sparkGlyphs)-1))
+		out[i] = sparkGlyphs[idx]
+	}
+	return string(out)
+}
@@ -0,0 +1,81 @@
+// Package promscrape periodically scrapes Prometheus text-format /metrics
+// endpoints exposed by Atlas services and keeps a rolling history of the
+// metric values the control panel promotes to its Stats view, so the
+// scraper can also be reused headlessly (e.g. from the cobra CLI).
+package promscrape
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/prometheus/common/expfmt"
+)
+
+// Sample is one named metric value read off a scrape.
+type Sample struct {
+	Service string
+	Name    string
+	Value   float64
+}
+
+// Scrape fetches target's /metrics endpoint and extracts the requested
+// metric names. Metrics with more than one label combination (e.g. a
+// counter vector) are summed, since the TUI only tracks a single series per
+// promoted name.
+func Scrape(ctx context.Context, service string, cfg ServiceConfig) ([]Sample, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, cfg.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build request for %s: %w", service, err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("scrape %s: %w", service, err)
+	}
+	defer resp.Body.Close()
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s metrics: %w", service, err)
+	}
+
+	wanted := make(map[string]bool, len(cfg.Metrics))
+	for _, name := range cfg.Metrics {
+		wanted[name] = true
+	}
+
+	var samples []Sample
+	for name, family := range families {
+		if !wanted[name] {
+			continue
+		}
+		var total float64
+		for _, m := range family.GetMetric() {
+			switch {
+			case m.GetCounter() != nil:
+				total += m.GetCounter().GetValue()
+			case m.GetGauge() != nil:
+				total += m.GetGauge().GetValue()
+			}
+		}
+		samples = append(samples, Sample{Service: service, Name: name, Value: total})
+	}
+	return samples, nil
+}
+
+// ScrapeAll scrapes every configured service and returns whatever samples
+// were read successfully; a single unreachable service does not fail the
+// whole round.
+func ScrapeAll(ctx context.Context, cfg *Config) []Sample {
+	var all []Sample
+	for service, svcCfg := range cfg.Services {
+		samples, err := Scrape(ctx, service, svcCfg)
+		if err != nil {
+			continue
+		}
+		all = append(all, samples...)
+	}
+	return all
+}
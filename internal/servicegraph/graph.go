@@ -0,0 +1,185 @@
+// Package servicegraph models the dependency DAG between services
+// (mirroring Pulumi's resource-dependency graph) so the TUI can render a
+// topologically ordered, live-updating plan instead of a flat list.
+package servicegraph
+
+import "sync"
+
+// Graph is a dependency DAG keyed by service name.
+type Graph struct {
+	dependsOn map[string][]string
+	order     []string // insertion order, used as a tie-breaker in TopoOrder
+}
+
+// New builds a Graph from a name -> dependencies map. names gives the
+// preferred ordering for services that have no relative ordering
+// constraint between them.
+func New(dependsOn map[string][]string, names []string) *Graph {
+	g := &Graph{dependsOn: make(map[string][]string, len(dependsOn)), order: names}
+	for name, deps := range dependsOn {
+		g.dependsOn[name] = append([]string(nil), deps...)
+	}
+	return g
+}
+
+// DependsOn returns the direct dependencies of name.
+func (g *Graph) DependsOn(name string) []string {
+	return g.dependsOn[name]
+}
+
+// TopoOrder returns service names in a depth-first topological order:
+// every service appears after everything it depends on. Services are
+// visited in the order of the names list passed to New, each one preceded
+// by any of its dependencies not already listed, so a dependency can move
+// ahead of a service that came before it in names.
+func (g *Graph) TopoOrder() []string {
+	visited := make(map[string]bool, len(g.order))
+	var out []string
+
+	var visit func(name string)
+	visit = func(name string) {
+		if visited[name] {
+			return
+		}
+		visited[name] = true
+		for _, dep := range g.dependsOn[name] {
+			visit(dep)
+		}
+		out = append(out, name)
+	}
+
+	for _, name := range g.order {
+		visit(name)
+	}
+	return out
+}
+
+// Depth returns the length of the longest dependency chain below name (0
+// for a service with no dependencies), used to indent the Plan tree view.
+func (g *Graph) Depth(name string) int {
+	deps := g.dependsOn[name]
+	if len(deps) == 0 {
+		return 0
+	}
+	max := 0
+	for _, dep := range deps {
+		if d := g.Depth(dep); d > max {
+			max = d
+		}
+	}
+	return max + 1
+}
+
+// Descendants returns every service that transitively depends on name,
+// i.e. the set that should be marked "blocked" if name fails to start.
+func (g *Graph) Descendants(name string) []string {
+	var out []string
+	seen := make(map[string]bool)
+
+	var visitChildren func(target string)
+	visitChildren = func(target string) {
+		for svc, deps := range g.dependsOn {
+			for _, dep := range deps {
+				if dep == target && !seen[svc] {
+					seen[svc] = true
+					out = append(out, svc)
+					visitChildren(svc)
+				}
+			}
+		}
+	}
+	visitChildren(name)
+	return out
+}
+
+// State is the live status of a node in the resource plan, mirroring
+// Pulumi's preview/update vocabulary.
+type State int
+
+const (
+	StatePending State = iota
+	StateCreating
+	StateUpdating
+	StateOK
+	StateFailed
+	StateBlocked // a parent failed, so this node will not be attempted
+)
+
+func (s State) String() string {
+	switch s {
+	case StatePending:
+		return "pending"
+	case StateCreating:
+		return "creating"
+	case StateUpdating:
+		return "updating"
+	case StateOK:
+		return "ok"
+	case StateFailed:
+		return "failed"
+	case StateBlocked:
+		return "blocked"
+	default:
+		return "unknown"
+	}
+}
+
+// StateStore is a mutex-protected registry of per-service plan state that
+// the Bubble Tea model polls on every tick to render the Plan view.
+type StateStore struct {
+	mu     sync.Mutex
+	states map[string]State
+}
+
+// NewStateStore returns a StateStore with every known service pending.
+func NewStateStore(names []string) *StateStore {
+	s := &StateStore{states: make(map[string]State, len(names))}
+	for _, name := range names {
+		s.states[name] = StatePending
+	}
+	return s
+}
+
+// Set transitions name to state.
+func (s *StateStore) Set(name string, state State) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.states[name] = state
+}
+
+// Get returns the current state of name (StatePending if unknown).
+func (s *StateStore) Get(name string) State {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.states[name]
+}
+
+// Snapshot returns a copy of the full state map.
+func (s *StateStore) Snapshot() map[string]State {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make(map[string]State, len(s.states))
+	for name, state := range s.states {
+		out[name] = state
+	}
+	return out
+}
+
+// Reset returns every known service to StatePending.
+func (s *StateStore) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for name := range s.states {
+		s.states[name] = StatePending
+	}
+}
+
+// FailAndBlock marks name as StateFailed and every descendant in g as
+// StateBlocked, so a failed parent visibly stops its children rather than
+// leaving them silently pending.
+func (s *StateStore) FailAndBlock(g *Graph, name string) {
+	s.Set(name, StateFailed)
+	for _, child := range g.Descendants(name) {
+		s.Set(child, StateBlocked)
+	}
+}
@@ -5,17 +5,84 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"regexp"
 	"runtime"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
+	composetypes "github.com/compose-spec/compose-go/types"
+
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/shirou/gopsutil/v3/cpu"
 	"github.com/shirou/gopsutil/v3/disk"
 	"github.com/shirou/gopsutil/v3/mem"
+
+	"github.com/KumarVandit/data_hackathon/internal/analyzer"
+	"github.com/KumarVandit/data_hackathon/internal/containerstats"
+	"github.com/KumarVandit/data_hackathon/internal/dockerapi"
+	"github.com/KumarVandit/data_hackathon/internal/formatter"
+	"github.com/KumarVandit/data_hackathon/internal/graphstats"
+	"github.com/KumarVandit/data_hackathon/internal/logstream"
+	"github.com/KumarVandit/data_hackathon/internal/metrics"
+	"github.com/KumarVandit/data_hackathon/internal/otlpsink"
+	"github.com/KumarVandit/data_hackathon/internal/probe"
+	"github.com/KumarVandit/data_hackathon/internal/procstats"
+	"github.com/KumarVandit/data_hackathon/internal/progress"
+	"github.com/KumarVandit/data_hackathon/internal/promscrape"
+	"github.com/KumarVandit/data_hackathon/internal/servicegraph"
+	"github.com/KumarVandit/data_hackathon/pkg/lifecycle"
+)
+
+const composeFilePath = "docker-compose.yml"
+
+var (
+	composeProjectOnce sync.Once
+	composeProject     *composetypes.Project
+	composeProjectErr  error
 )
 
+// loadComposeProject parses docker-compose.yml once per process via
+// compose-go and caches the result, so every Docker Engine API call that
+// needs a service's definition (image, env, ports, build context) shares
+// one parsed project instead of re-reading the file.
+func loadComposeProject() (*composetypes.Project, error) {
+	composeProjectOnce.Do(func() {
+		composeProject, composeProjectErr = dockerapi.LoadProject(composeFilePath)
+	})
+	return composeProject, composeProjectErr
+}
+
+// falkordbAddr resolves the host:port FalkorDB's redis-protocol port (6379)
+// is published on, discovered from the compose project the same way
+// portBindings reads each service's port mappings; it falls back to the
+// project's default dev-compose mapping if the project can't be loaded or
+// the port isn't published.
+func falkordbAddr() string {
+	const defaultAddr = "localhost:6379"
+	project, err := loadComposeProject()
+	if err != nil {
+		return defaultAddr
+	}
+	svc, err := project.GetService("falkordb")
+	if err != nil {
+		return defaultAddr
+	}
+	for _, p := range svc.Ports {
+		if p.Target == 6379 && p.Published != 0 {
+			return fmt.Sprintf("localhost:%d", p.Published)
+		}
+	}
+	return defaultAddr
+}
+
+const metricsConfigPath = "config/metrics.yaml"
+const otlpConfigPath = "config/otlp.yaml"
+const probesConfigPath = "config/probes.yaml"
+const maxTraces = 50
+
 const (
 	viewServices = iota
 	viewLogs
@@ -24,8 +91,14 @@ const (
 	viewConfig
 	viewPipeline
 	viewDashboard
+	viewPlan
+	viewTraces
+	viewAnalyze
+	viewProcesses
 )
 
+const numViews = viewProcesses + 1
+
 var (
 	titleStyle = lipgloss.NewStyle().
 			Bold(true).
@@ -73,14 +146,47 @@ type model struct {
 	lastUpdate     time.Time
 	statusMsg      string
 	statusType     string
-	logs           map[string][]string
 	logViewer      string // which service logs are being viewed
 	graphStats     graphStats
+	graphCollector *graphstats.Collector
+	// Streaming container log follower (chunk2-6), one ring buffer per
+	// service and a cancel func for whichever follower is currently live.
+	logBuffers        map[string]*logstream.RingBuffer
+	logFollowCancel   context.CancelFunc
+	logFilterMode     bool
+	logFilterInput    string
+	logFilter         string
+	logFilterRe       *regexp.Regexp
+	logErrorsOnly     bool
+	logPaused         bool
+	logPausedSnapshot []logstream.Line
 	// Startup progress tracking
 	startupInProgress bool
-	startupProgress   map[string]startupProgress
-	startupStartTime  time.Time
-	startupLogs       []string
+	progressWriter    *progress.Writer
+	startupCancel     context.CancelFunc
+	serviceGraph      *servicegraph.Graph
+	planStates        *servicegraph.StateStore
+	// Prometheus sparkline panel
+	promConfig     *promscrape.Config
+	promHistories  map[string]*promscrape.History
+	promDetailMode bool
+	promSelected   int
+	// OTLP log/trace sink
+	otlpConfig otlpsink.Config
+	otlpLogs   chan otlpsink.LogEvent
+	otlpSpans  chan otlpsink.SpanEvent
+	traces     []otlpsink.SpanEvent
+	// Streaming container stats
+	containerStatUpdates chan containerstats.Update
+	// Per-process resource attribution
+	procStats    *procstats.Collector
+	processStats map[string][]procstats.Info
+	// Stats panel output format (table/json/json-stream/template)
+	outputFormat formatter.Format
+	// Analyze view
+	findings []analyzer.Finding
+	// Kubernetes-style readiness probes
+	probeConfig *probe.Config
 }
 
 type service struct {
@@ -91,6 +197,17 @@ type service struct {
 	containerID string
 	uptime      string
 	health      string
+	dependsOn   []string // names of services this one depends on
+}
+
+// serviceDependsOn is the first-class form of what the old depsInfo map
+// described as italic strings: which services must be up before another
+// can start. It backs both the Services view's "Depends on:" hints and
+// the Plan view's resource tree.
+var serviceDependsOn = map[string][]string{
+	"Graphiti MCP":    {"FalkorDB", "Ollama"},
+	"Atlas Engine":    {"Graphiti MCP", "FalkorDB"},
+	"Atlas Dashboard": {"Graphiti MCP", "FalkorDB"},
 }
 
 type systemStats struct {
@@ -105,25 +222,24 @@ type systemStats struct {
 }
 
 type containerStat struct {
-	cpuPercent float64
-	memPercent float64
-	memUsage   string
-	status     string
+	cpuPercent    float64
+	memPercent    float64
+	memUsage      string
+	memUsageBytes uint64
+	status        string
+	netRx         uint64
+	netTx         uint64
+	blockRead     uint64
+	blockWrite    uint64
+	pids          uint64
 }
 
 type graphStats struct {
-	nodeCount  int
-	edgeCount  int
-	lastUpdate string
-}
-
-type startupProgress struct {
-	serviceName    string
-	step           string  // "starting", "waiting_health", "completed", "failed"
-	progress       float64 // 0.0 to 1.0
-	elapsedTime    time.Duration
-	estimatedTotal time.Duration
-	logs           []string
+	nodeCount   int
+	edgeCount   int
+	memoryUsage string
+	slowQueries []graphstats.SlowQuery
+	lastUpdate  string
 }
 
 type tickMsg time.Time
@@ -132,32 +248,111 @@ type statusMsg struct {
 	msgType string
 }
 
-type progressMsg struct {
-	progress startupProgress
+// defaultServices returns the known Atlas services in startup order, each
+// carrying its dependsOn edges so the Services and Plan views stay in
+// sync with a single source of truth.
+func defaultServices() []service {
+	services := []service{
+		{name: "Ollama", port: "11434", url: "http://localhost:11434"},
+		{name: "FalkorDB", port: "6379", url: "http://localhost:3000"},
+		{name: "Graphiti MCP", port: "8000", url: "http://localhost:8000"},
+		{name: "Atlas Engine", port: "", url: ""},
+		{name: "Atlas Dashboard", port: "5173 (UI) / 8001 (API)", url: "http://localhost:5173"},
+	}
+	for i := range services {
+		services[i].dependsOn = serviceDependsOn[services[i].name]
+	}
+	return services
+}
+
+// newServiceGraph builds the dependency DAG for the default service set.
+func newServiceGraph() *servicegraph.Graph {
+	names := make([]string, 0, len(defaultServices()))
+	dependsOn := make(map[string][]string, len(serviceDependsOn))
+	for _, svc := range defaultServices() {
+		names = append(names, svc.name)
+		dependsOn[svc.name] = svc.dependsOn
+	}
+	return servicegraph.New(dependsOn, names)
+}
+
+// loadMetricsConfig reads config/metrics.yaml, falling back to
+// promscrape.DefaultConfig so the sparkline panel still works out of the box.
+func loadMetricsConfig() *promscrape.Config {
+	cfg, err := promscrape.LoadConfig(metricsConfigPath)
+	if err != nil {
+		return promscrape.DefaultConfig()
+	}
+	return cfg
+}
+
+// loadOtlpConfig reads config/otlp.yaml, falling back to
+// otlpsink.DefaultConfig so the receiver still runs out of the box.
+func loadOtlpConfig() otlpsink.Config {
+	cfg, err := otlpsink.LoadConfig(otlpConfigPath)
+	if err != nil {
+		return otlpsink.DefaultConfig()
+	}
+	return cfg
+}
+
+// loadProbeConfig reads config/probes.yaml, falling back to
+// probe.DefaultConfig so readiness checks still work out of the box.
+func loadProbeConfig() *probe.Config {
+	cfg, err := probe.LoadConfig(probesConfigPath)
+	if err != nil {
+		return probe.DefaultConfig()
+	}
+	return cfg
+}
+
+// runProbes reports whether serviceName is ready. If a probe.Spec is
+// configured for it, every probe in the spec is run once; otherwise this
+// falls back to Docker's own health field, since Atlas Engine and Graphiti
+// MCP don't have probes defined yet.
+func runProbes(ctx context.Context, serviceName string, cfg *probe.Config) bool {
+	if spec, ok := cfg.Services[serviceName]; ok {
+		return spec.CheckOnce(ctx, serviceName)
+	}
+	running, info := getContainerInfo(serviceName)
+	return running && (info.health == "healthy" || info.health == "running")
 }
 
-func initialModel() model {
+func initialModel(format formatter.Format) model {
+	graph := newServiceGraph()
+	services := defaultServices()
+	names := make([]string, len(services))
+	for i, svc := range services {
+		names[i] = svc.name
+	}
+
 	return model{
-		currentView: viewServices,
-		selected:    0,
-		services: []service{
-			{name: "Ollama", port: "11434", url: "http://localhost:11434"},
-			{name: "FalkorDB", port: "6379", url: "http://localhost:3000"},
-			{name: "Graphiti MCP", port: "8000", url: "http://localhost:8000"},
-			{name: "Atlas Engine", port: "", url: ""},
-			{name: "Atlas Dashboard", port: "5173 (UI) / 8001 (API)", url: "http://localhost:5173"},
-		},
-		stats:             systemStats{},
-		containerStats:    make(map[string]containerStat),
-		lastUpdate:        time.Now(),
-		statusMsg:         "Ready",
-		statusType:        "success",
-		logs:              make(map[string][]string),
-		logViewer:         "",
-		graphStats:        graphStats{},
-		startupInProgress: false,
-		startupProgress:   make(map[string]startupProgress),
-		startupLogs:       make([]string, 0),
+		currentView:          viewServices,
+		selected:             0,
+		services:             services,
+		serviceGraph:         graph,
+		planStates:           servicegraph.NewStateStore(names),
+		stats:                systemStats{},
+		containerStats:       make(map[string]containerStat),
+		lastUpdate:           time.Now(),
+		statusMsg:            "Ready",
+		statusType:           "success",
+		logViewer:            "",
+		graphStats:           graphStats{},
+		graphCollector:       graphstats.NewCollector(falkordbAddr()),
+		logBuffers:           make(map[string]*logstream.RingBuffer),
+		startupInProgress:    false,
+		progressWriter:       progress.NewWriter(os.Stdout, false),
+		promConfig:           loadMetricsConfig(),
+		promHistories:        make(map[string]*promscrape.History),
+		otlpConfig:           loadOtlpConfig(),
+		otlpLogs:             make(chan otlpsink.LogEvent, 256),
+		otlpSpans:            make(chan otlpsink.SpanEvent, 256),
+		probeConfig:          loadProbeConfig(),
+		containerStatUpdates: make(chan containerstats.Update, 256),
+		procStats:            procstats.NewCollector(),
+		processStats:         make(map[string][]procstats.Info),
+		outputFormat:         format,
 	}
 }
 
@@ -165,24 +360,54 @@ func (m model) Init() tea.Cmd {
 	return tea.Batch(
 		checkServices(),
 		updateStats(),
-		updateContainerStats(),
 		tick(),
+		startOtlpSink(m.otlpConfig, m.otlpLogs, m.otlpSpans),
+		listenOtlpLogs(m.otlpLogs),
+		listenOtlpSpans(m.otlpSpans),
+		startContainerStatsCollector(m.containerStatUpdates),
+		listenContainerStats(m.containerStatUpdates),
+		updateProcessStats(m.services, m.procStats),
 	)
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.logFilterMode {
+			switch msg.String() {
+			case "enter":
+				m.logFilterMode = false
+				m.logFilter = m.logFilterInput
+				if re, err := regexp.Compile(m.logFilterInput); err == nil {
+					m.logFilterRe = re
+				} else {
+					m.logFilterRe = nil
+				}
+			case "esc":
+				m.logFilterMode = false
+				m.logFilterInput = ""
+			case "backspace":
+				if len(m.logFilterInput) > 0 {
+					m.logFilterInput = m.logFilterInput[:len(m.logFilterInput)-1]
+				}
+			default:
+				if len(msg.String()) == 1 {
+					m.logFilterInput += msg.String()
+				}
+			}
+			return m, nil
+		}
+
 		switch msg.String() {
 		case "ctrl+c", "q":
 			return m, tea.Quit
 		case "tab":
 			// Switch views
-			m.currentView = (m.currentView + 1) % 7
+			m.currentView = (m.currentView + 1) % numViews
 			m.selected = 0
 			return m, nil
 		case "shift+tab":
-			m.currentView = (m.currentView + 6) % 7
+			m.currentView = (m.currentView + numViews - 1) % numViews
 			m.selected = 0
 			return m, nil
 		case "1":
@@ -195,10 +420,10 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		case "3":
 			m.currentView = viewStats
-			return m, tea.Batch(updateStats(), updateContainerStats())
+			return m, updateStats()
 		case "4":
 			m.currentView = viewGraph
-			return m, updateGraphStats()
+			return m, updateGraphStats(m.graphCollector)
 		case "5":
 			m.currentView = viewConfig
 			return m, nil
@@ -208,11 +433,28 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "7":
 			m.currentView = viewPipeline
 			return m, nil
+		case "8":
+			m.currentView = viewPlan
+			return m, nil
+		case "9":
+			m.currentView = viewTraces
+			return m, nil
+		case "0":
+			m.currentView = viewAnalyze
+			return m, nil
 		case "up", "k":
+			if m.currentView == viewStats && m.promDetailMode {
+				m.promSelected = m.promCycle(-1)
+				return m, nil
+			}
 			if m.selected > 0 {
 				m.selected--
 			}
 		case "down", "j":
+			if m.currentView == viewStats && m.promDetailMode {
+				m.promSelected = m.promCycle(1)
+				return m, nil
+			}
 			maxSelection := m.getMaxSelection()
 			if m.selected < maxSelection {
 				m.selected++
@@ -220,30 +462,92 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "enter", " ":
 			return m, m.handleAction()
 		case "r":
+			if m.currentView == viewProcesses {
+				return m, updateProcessStats(m.services, m.procStats)
+			}
 			return m, checkServices()
+		case "m":
+			if m.currentView == viewStats {
+				m.promDetailMode = !m.promDetailMode
+			}
+			return m, nil
+		case "f":
+			if m.currentView == viewStats || m.currentView == viewPipeline {
+				m.outputFormat = nextStatsFormat(m.outputFormat)
+			}
+			return m, nil
 		case "s":
 			m.startupInProgress = true
-			m.startupStartTime = time.Now()
-			m.startupProgress = make(map[string]startupProgress)
-			m.startupLogs = []string{"Initializing service startup..."}
+			m.progressWriter.Reset()
+			m.planStates.Reset()
+			ctx, cancel := context.WithCancel(context.Background())
+			m.startupCancel = cancel
 			return m, tea.Batch(
-				startServicesWithProgress(),
+				startServicesWithProgress(ctx, m.progressWriter, m.probeConfig),
 				func() tea.Msg { return statusMsg{message: "Starting all core services...", msgType: "info"} },
 			)
 		case "x":
 			return m, stopServices()
 		case "b":
-			return m, buildServices()
+			m.startupInProgress = true
+			m.progressWriter.Reset()
+			m.planStates.Reset()
+			return m, buildServices(m.progressWriter)
 		case "p":
-			return m, startProcessing()
+			if m.currentView == viewLogs {
+				m.logPaused = !m.logPaused
+				if m.logPaused {
+					if buf, ok := m.logBuffers[m.logViewer]; ok {
+						m.logPausedSnapshot = buf.Lines()
+					}
+				} else {
+					m.logPausedSnapshot = nil
+				}
+				return m, nil
+			}
+			m.startupInProgress = true
+			m.progressWriter.Reset()
+			m.planStates.Reset()
+			ctx, cancel := context.WithCancel(context.Background())
+			m.startupCancel = cancel
+			return m, startProcessing(ctx, m.progressWriter, m.probeConfig)
 		case "d":
-			return m, startDashboard()
+			m.startupInProgress = true
+			m.progressWriter.Reset()
+			m.planStates.Reset()
+			return m, startDashboard(m.progressWriter, m.probeConfig)
+		case "esc":
+			if m.startupInProgress && m.startupCancel != nil {
+				m.startupCancel()
+				m.startupCancel = nil
+				return m, func() tea.Msg { return statusMsg{message: "Startup canceled", msgType: "warn"} }
+			}
 		case "l":
 			if m.currentView == viewServices && m.selected < len(m.services) {
-				m.logViewer = m.services[m.selected].name
+				svc := m.services[m.selected]
+				m.logViewer = svc.name
 				m.currentView = viewLogs
-				return m, fetchLogs(m.services[m.selected].name)
+				if m.logFollowCancel != nil {
+					m.logFollowCancel()
+				}
+				ctx, cancel := context.WithCancel(context.Background())
+				m.logFollowCancel = cancel
+				if m.logBuffers[svc.name] == nil {
+					m.logBuffers[svc.name] = logstream.NewRingBuffer(logstream.DefaultCapacity)
+				}
+				return m, startLogFollow(ctx, svc.name, m.logBuffers[svc.name])
+			}
+		case "/":
+			if m.currentView == viewLogs {
+				m.logFilterMode = true
+				m.logFilterInput = m.logFilter
+			}
+			return m, nil
+		case "e":
+			if m.currentView == viewLogs {
+				m.logErrorsOnly = !m.logErrorsOnly
 			}
+			return m, nil
 		case "R":
 			if m.currentView == viewServices && m.selected < len(m.services) {
 				return m, restartService(m.services[m.selected].name)
@@ -252,19 +556,22 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case tickMsg:
 		cmds := []tea.Cmd{updateStats(), tick()}
-		// Always update container stats, not just in stats view
-		cmds = append(cmds, updateContainerStats())
-		if m.currentView == viewLogs && m.logViewer != "" {
-			cmds = append(cmds, fetchLogs(m.logViewer))
-		}
-		// Update elapsed time for startup progress
+		cmds = append(cmds, scrapePromMetrics(m.promConfig))
+		cmds = append(cmds, runAnalysis(m))
+		if m.currentView == viewProcesses {
+			cmds = append(cmds, updateProcessStats(m.services, m.procStats))
+		}
+		// The vertex progress writer tracks its own elapsed time per
+		// vertex; on every tick we just need to notice that every vertex
+		// has reached a terminal state and flip the overall flag.
 		if m.startupInProgress {
-			// Update elapsed times for all services in progress
-			for name, progress := range m.startupProgress {
-				if progress.step != "completed" && progress.step != "failed" {
-					progress.elapsedTime = time.Since(m.startupStartTime)
-					m.startupProgress[name] = progress
-				}
+			m.syncPlanStates()
+			if m.progressWriter.Done() {
+				m.startupInProgress = false
+				cmds = append(cmds,
+					checkServices(),
+					func() tea.Msg { return statusMsg{message: "All services started successfully", msgType: "success"} },
+				)
 			}
 		}
 		return m, tea.Batch(cmds...)
@@ -279,59 +586,66 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.lastUpdate = time.Now()
 		return m, nil
 
-	case map[string]containerStat:
-		m.containerStats = msg
-		return m, nil
-
 	case []service:
 		m.services = msg
 		return m, nil
 
-	case logMsg:
-		m.logs[msg.service] = msg.lines
-		return m, nil
-
 	case graphStats:
 		m.graphStats = msg
 		return m, nil
 
-	case progressMsg:
-		m.startupProgress[msg.progress.serviceName] = msg.progress
-		if len(msg.progress.logs) > 0 {
-			// Append new logs to startup logs
-			m.startupLogs = append(m.startupLogs, msg.progress.logs...)
-			// Keep only last 100 lines
-			if len(m.startupLogs) > 100 {
-				m.startupLogs = m.startupLogs[len(m.startupLogs)-100:]
+	case []promscrape.Sample:
+		for _, sample := range msg {
+			if m.promHistories[sample.Name] == nil {
+				m.promHistories[sample.Name] = promscrape.NewHistory()
 			}
+			m.promHistories[sample.Name].Push(sample.Value)
 		}
-		// Continue reading from channel if startup is still in progress
-		var cmd tea.Cmd = nil
-		if m.startupInProgress {
-			// Check if all services are completed
-			allCompleted := true
-			completedCount := 0
-			for _, progress := range m.startupProgress {
-				if progress.step == "completed" || progress.step == "failed" {
-					completedCount++
-				}
-				if progress.step != "completed" && progress.step != "failed" {
-					allCompleted = false
-				}
-			}
-			// If we have progress for all 3 services and all are done
-			if completedCount >= 3 && allCompleted {
-				m.startupInProgress = false
-				cmd = tea.Batch(
-					checkServices(),
-					func() tea.Msg { return statusMsg{message: "All services started successfully", msgType: "success"} },
-				)
-			} else {
-				// Continue reading progress messages
-				cmd = readNextStartupProgress()
+		return m, nil
+
+	case otlpLogMsg:
+		line := fmt.Sprintf("[%s] %s %s", msg.Timestamp.Format("15:04:05"), msg.Severity, msg.Body)
+		if m.logBuffers[msg.Service] == nil {
+			m.logBuffers[msg.Service] = logstream.NewRingBuffer(logstream.DefaultCapacity)
+		}
+		m.logBuffers[msg.Service].Push(logstream.Line{Text: line, Level: strings.ToUpper(msg.Severity)})
+		return m, listenOtlpLogs(m.otlpLogs)
+
+	case otlpSpanMsg:
+		m.traces = append(m.traces, otlpsink.SpanEvent(msg))
+		if len(m.traces) > maxTraces {
+			m.traces = m.traces[len(m.traces)-maxTraces:]
+		}
+		return m, listenOtlpSpans(m.otlpSpans)
+
+	case containerStatMsg:
+		if msg.Removed {
+			delete(m.containerStats, msg.Name)
+			metrics.DeleteContainer(msg.Name)
+		} else {
+			m.containerStats[msg.Name] = containerStat{
+				cpuPercent:    msg.Stat.CPUPercent,
+				memPercent:    msg.Stat.MemPercent,
+				memUsage:      msg.Stat.MemUsage,
+				memUsageBytes: msg.Stat.MemUsageBytes,
+				status:        msg.Stat.Status,
+				netRx:         msg.Stat.NetRx,
+				netTx:         msg.Stat.NetTx,
+				blockRead:     msg.Stat.BlockRead,
+				blockWrite:    msg.Stat.BlockWrite,
+				pids:          msg.Stat.Pids,
 			}
+			metrics.SetContainerStats(msg.Name, msg.Stat.CPUPercent, msg.Stat.MemPercent, msg.Stat.MemUsageBytes)
 		}
-		return m, cmd
+		return m, listenContainerStats(m.containerStatUpdates)
+
+	case findingsMsg:
+		m.findings = []analyzer.Finding(msg)
+		return m, nil
+
+	case processStatsMsg:
+		m.processStats = msg
+		return m, nil
 	}
 
 	return m, nil
@@ -362,6 +676,14 @@ func (m model) View() string {
 		s += m.renderPipelineView()
 	case viewDashboard:
 		s += m.renderDashboardView()
+	case viewPlan:
+		s += m.renderPlanView()
+	case viewTraces:
+		s += m.renderTracesView()
+	case viewAnalyze:
+		s += m.renderAnalyzeView()
+	case viewProcesses:
+		s += m.renderProcessesView()
 	}
 
 	// Status bar
@@ -371,7 +693,7 @@ func (m model) View() string {
 }
 
 func (m model) renderTabs() string {
-	tabs := []string{"Services (1)", "Logs (2)", "Stats (3)", "Graph (4)", "Config (5)", "Dashboard (6)"}
+	tabs := []string{"Services (1)", "Logs (2)", "Stats (3)", "Graph (4)", "Config (5)", "Pipeline (7)", "Dashboard (6)", "Plan (8)", "Traces (9)", "Analyze (0)", "Processes"}
 	var rendered []string
 	for i, tab := range tabs {
 		if i == m.currentView {
@@ -399,26 +721,19 @@ func (m model) renderServicesView() string {
 func (m model) renderServices() string {
 	var s string
 
-	// Service dependencies info
-	depsInfo := map[string]string{
-		"Graphiti MCP":    "‚Üí Depends on: FalkorDB, Ollama",
-		"Atlas Engine":    "‚Üí Depends on: Graphiti MCP, FalkorDB",
-		"Atlas Dashboard": "‚Üí Depends on: Graphiti MCP, FalkorDB",
-	}
-
 	for i, svc := range m.services {
 		cursor := " "
 		if i == m.selected {
 			cursor = "‚ñ∂"
 		}
 
-		statusIcon := "‚óã"
+		statusIcon := "○"
 		statusColor := "240"
 		if svc.status == "running" {
 			statusIcon = "‚óè"
 			statusColor = "42"
 		} else if svc.status == "stopped" {
-			statusIcon = "‚óã"
+			statusIcon = "○"
 			statusColor = "196"
 		}
 
@@ -443,9 +758,9 @@ func (m model) renderServices() string {
 		}
 
 		// Add dependency info
-		if depInfo, ok := depsInfo[svc.name]; ok {
+		if len(svc.dependsOn) > 0 {
 			depStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Italic(true)
-			info += "\n  " + depStyle.Render(depInfo)
+			info += "\n  " + depStyle.Render("→ Depends on: "+strings.Join(svc.dependsOn, ", "))
 		}
 
 		s += fmt.Sprintf("%s %s\n", cursor, info)
@@ -470,105 +785,66 @@ func (m model) renderServices() string {
 	return s
 }
 
+// renderStartupProgress renders the active progress.Writer as a BuildKit
+// vertex list: one header line per vertex (glyph, name, elapsed) with a
+// rolling log window underneath while it's running, collapsing to a
+// single summary line once it reaches a terminal state. Bubble Tea
+// re-renders the whole View() on every tickMsg, so this just reflects the
+// Writer's current state back out; Bubble Tea's own renderer diffs that
+// against the previous frame and repaints only what changed.
 func (m model) renderStartupProgress() string {
 	if !m.startupInProgress {
 		return ""
 	}
 
-	var progressBar strings.Builder
-	progressBar.WriteString("üöÄ Starting Services\n\n")
-
-	// Calculate overall progress
-	totalProgress := 0.0
-	totalServices := len(m.startupProgress)
-	if totalServices > 0 {
-		for _, p := range m.startupProgress {
-			totalProgress += p.progress
-		}
-		totalProgress = totalProgress / float64(totalServices)
-	}
+	vertices := m.progressWriter.Snapshot()
 
-	// Overall progress bar
-	barWidth := 40
-	filled := int(totalProgress * float64(barWidth))
-	bar := strings.Repeat("‚ñà", filled) + strings.Repeat("‚ñë", barWidth-filled)
-	progressBar.WriteString(fmt.Sprintf("Overall Progress: [%s] %.1f%%\n\n", bar, totalProgress*100))
+	var b strings.Builder
+	b.WriteString("🚀 Starting Services\n\n")
 
-	// Service-by-service progress
-	services := []string{"FalkorDB", "Ollama", "Graphiti MCP"}
-	for _, svcName := range services {
-		progress, exists := m.startupProgress[svcName]
-		if !exists {
-			progress = startupProgress{
-				serviceName: svcName,
-				step:        "pending",
-				progress:    0.0,
-			}
+	done := 0
+	for _, v := range vertices {
+		if v.State == progress.StateDone || v.State == progress.StateError {
+			done++
 		}
-
-		// Status icon
-		statusIcon := "‚óã"
-		statusColor := "240"
-		stepText := ""
-		switch progress.step {
-		case "starting":
-			statusIcon = "‚ü≥"
-			statusColor = "214"
-			stepText = "Starting..."
-		case "waiting_health":
-			statusIcon = "‚ü≥"
-			statusColor = "214"
-			stepText = "Waiting for health check..."
-		case "completed":
-			statusIcon = "‚úì"
-			statusColor = "42"
-			stepText = "Completed"
-		case "failed":
-			statusIcon = "‚úó"
-			statusColor = "196"
-			stepText = "Failed"
+	}
+	b.WriteString(fmt.Sprintf("%d/%d vertices complete\n\n", done, len(vertices)))
+
+	for _, v := range vertices {
+		color := "240"
+		switch v.State {
+		case progress.StateRunning:
+			color = "214"
+		case progress.StateDone:
+			color = "42"
+		case progress.StateError:
+			color = "196"
+		}
+		glyphStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(color))
+
+		header := fmt.Sprintf("%s %s", glyphStyle.Render(v.State.Glyph()), v.Name)
+		if v.Elapsed > 0 {
+			header += fmt.Sprintf(" (%v)", v.Elapsed.Round(time.Second))
+		}
+		b.WriteString(header + "\n")
+
+		switch v.State {
+		case progress.StateDone:
+			b.WriteString("    └ completed\n")
+		case progress.StateError:
+			b.WriteString(fmt.Sprintf("    └ failed: %v\n", v.Err))
 		default:
-			stepText = "Pending"
-		}
-
-		statusStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(statusColor))
-		progressBar.WriteString(fmt.Sprintf("  %s %s %s\n", statusStyle.Render(statusIcon), svcName, stepText))
-
-		// Service-specific progress bar
-		svcBarWidth := 30
-		svcFilled := int(progress.progress * float64(svcBarWidth))
-		svcBar := strings.Repeat("‚ñà", svcFilled) + strings.Repeat("‚ñë", svcBarWidth-svcFilled)
-		progressBar.WriteString(fmt.Sprintf("    [%s] %.0f%%\n", svcBar, progress.progress*100))
-
-		// Time info
-		if progress.elapsedTime > 0 {
-			estimatedRemaining := progress.estimatedTotal - progress.elapsedTime
-			if estimatedRemaining < 0 {
-				estimatedRemaining = 0
+			logStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+			for _, line := range v.Logs {
+				b.WriteString("    " + logStyle.Render(line) + "\n")
 			}
-			progressBar.WriteString(fmt.Sprintf("    Elapsed: %v | Est. remaining: %v\n",
-				progress.elapsedTime.Round(time.Second), estimatedRemaining.Round(time.Second)))
-		}
-		progressBar.WriteString("\n")
-	}
-
-	// Show recent logs
-	if len(m.startupLogs) > 0 {
-		progressBar.WriteString("üìã Recent Logs:\n")
-		logCount := 10
-		if len(m.startupLogs) < logCount {
-			logCount = len(m.startupLogs)
-		}
-		logLines := m.startupLogs[len(m.startupLogs)-logCount:]
-		for _, logLine := range logLines {
-			progressBar.WriteString(fmt.Sprintf("  %s\n",
-				lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(logLine)))
 		}
 	}
 
-	return boxStyle.Render(progressBar.String())
+	return boxStyle.Render(b.String())
 }
 
+
 func (m model) renderLogsView() string {
 	header := "üìã Logs"
 	if m.logViewer != "" {
@@ -576,26 +852,56 @@ func (m model) renderLogsView() string {
 	} else if m.selected < len(m.services) {
 		header += fmt.Sprintf(": %s (Select service and press 'l')", m.services[m.selected].name)
 	}
+	if m.logErrorsOnly {
+		header += " [errors only]"
+	}
+	if m.logFilter != "" {
+		header += fmt.Sprintf(" [filter: %s]", m.logFilter)
+	}
+	if m.logPaused {
+		header += " [PAUSED]"
+	}
 
-	logLines := []string{}
-	if m.logViewer != "" {
-		logLines = m.logs[m.logViewer]
-		if len(logLines) > 50 {
-			logLines = logLines[len(logLines)-50:] // Last 50 lines
+	var lines []logstream.Line
+	switch {
+	case m.logPaused:
+		lines = m.logPausedSnapshot
+	case m.logViewer != "":
+		if buf, ok := m.logBuffers[m.logViewer]; ok {
+			lines = buf.Lines()
+		}
+	}
+	if len(lines) > 200 {
+		lines = lines[len(lines)-200:]
+	}
+
+	var visible []string
+	for _, l := range lines {
+		if m.logErrorsOnly && l.Level != "ERROR" {
+			continue
+		}
+		if m.logFilterRe != nil && !m.logFilterRe.MatchString(l.Text) {
+			continue
 		}
+		visible = append(visible, l.Text)
 	}
 
-	logContent := strings.Join(logLines, "\n")
+	logContent := strings.Join(visible, "\n")
 	if logContent == "" {
 		logContent = "No logs available. Select a service in Services view and press 'l' to view logs."
 	}
 
+	footer := "\n\n" + m.renderHelp("Logs")
+	if m.logFilterMode {
+		footer = fmt.Sprintf("\n\nFilter (regex): %s_\n\n", m.logFilterInput) + m.renderHelp("Logs")
+	}
+
 	logsBox := boxStyle.Render(
 		header + "\n\n" +
 			lipgloss.NewStyle().
 				Foreground(lipgloss.Color("240")).
 				Render(logContent) +
-			"\n\n" + m.renderHelp("Logs"),
+			footer,
 	)
 	return logsBox + "\n"
 }
@@ -616,52 +922,127 @@ func (m model) renderStatsView() string {
 			fmt.Sprintf("\n%s", infoStyle.Render(fmt.Sprintf("Updated: %s", m.lastUpdate.Format("15:04:05")))),
 	)
 
-	// Container stats
+	// Container stats, routed through the formatter package (chunk2-3) so
+	// the same CPUPerc/MemUsage/NetIO/BlockIO/PIDs accessors back both this
+	// interactive table and --format's json/json-stream/template output.
+	containerBox := boxStyle.Copy().Width(50).Render(m.renderContainerStats())
+
+	statsBox := lipgloss.JoinHorizontal(lipgloss.Top, systemBox, "  ", containerBox)
+	return statsBox + "\n" + m.renderPrometheusPanel() + "\n"
+}
+
+// renderContainerStats renders every service's container stats for the
+// Stats view's container panel. In the default table format it keeps the
+// existing colorized per-service breakdown, with the numeric text itself
+// sourced from the formatter package's CPUPerc/MemUsage/NetIO/BlockIO/PIDs
+// accessors; for json/json-stream/a custom template it hands the whole
+// service list to formatter.Render and returns that output verbatim.
+func (m model) renderContainerStats() string {
+	var contexts []formatter.StatsFormatContext
+	for _, svc := range m.services {
+		containerName := getContainerName(svc.name)
+		if stat, ok := m.containerStats[containerName]; ok {
+			contexts = append(contexts, containerStatContext(svc.name, stat))
+		}
+	}
+
+	if m.outputFormat != formatter.TableFormat && m.outputFormat != "" {
+		if len(contexts) == 0 {
+			return "üê≥ Container Resources\n\nWaiting for container stats...\n"
+		}
+		out, err := formatter.Render(m.outputFormat, contexts)
+		if err != nil {
+			return fmt.Sprintf("üê≥ Container Resources\n\nformat error: %v\n", err)
+		}
+		return "üê≥ Container Resources\n\n" + out
+	}
+
 	var containerInfo strings.Builder
-	containerInfo.WriteString("üê≥ Container Resources\n\n")
+	containerInfo.WriteString("üê≥ Container Resources\n\n")
 
 	hasStats := false
-	statsCount := 0
 	for _, svc := range m.services {
 		containerName := getContainerName(svc.name)
-		if stat, ok := m.containerStats[containerName]; ok {
-			hasStats = true
-			statsCount++
-			statusColor := "42"
-			if stat.status != "running" {
-				statusColor = "196"
+		stat, ok := m.containerStats[containerName]
+		if !ok {
+			if isContainerRunning(containerName) {
+				containerInfo.WriteString(fmt.Sprintf("%s: Collecting stats...\n\n", svc.name))
+			} else {
+				containerInfo.WriteString(fmt.Sprintf("%s: Not running\n\n", svc.name))
 			}
-			statusRender := lipgloss.NewStyle().Foreground(lipgloss.Color(statusColor)).Render(stat.status)
-			containerInfo.WriteString(fmt.Sprintf("%s:\n", svc.name))
-			containerInfo.WriteString(fmt.Sprintf("  CPU: %s\n", formatPercent(stat.cpuPercent)))
-			containerInfo.WriteString(fmt.Sprintf("  RAM: %s (%s)\n", formatPercent(stat.memPercent), stat.memUsage))
-			containerInfo.WriteString(fmt.Sprintf("  Status: %s\n\n", statusRender))
-		} else if isContainerRunning(containerName) {
-			// Container is running but stats not available yet
-			containerInfo.WriteString(fmt.Sprintf("%s: Collecting stats...\n\n", svc.name))
-		} else {
-			// Container not running
-			containerInfo.WriteString(fmt.Sprintf("%s: Not running\n\n", svc.name))
+			continue
+		}
+		hasStats = true
+		ctx := containerStatContext(svc.name, stat)
+		statusColor := "42"
+		if stat.status != "running" {
+			statusColor = "196"
 		}
+		statusRender := lipgloss.NewStyle().Foreground(lipgloss.Color(statusColor)).Render(stat.status)
+		containerInfo.WriteString(fmt.Sprintf("%s:\n", svc.name))
+		containerInfo.WriteString(fmt.Sprintf("  CPU: %s\n", ctx.CPUPerc()))
+		containerInfo.WriteString(fmt.Sprintf("  RAM: %s (%s)\n", ctx.MemPerc(), ctx.MemUsage()))
+		containerInfo.WriteString(fmt.Sprintf("  Net:   %s\n", ctx.NetIO()))
+		containerInfo.WriteString(fmt.Sprintf("  Block: %s\n", ctx.BlockIO()))
+		containerInfo.WriteString(fmt.Sprintf("  PIDs: %s\n", ctx.PIDs()))
+		containerInfo.WriteString(fmt.Sprintf("  Status: %s\n\n", statusRender))
 	}
 
-	if !hasStats && len(m.containerStats) == 0 {
+	if !hasStats {
 		containerInfo.WriteString("Waiting for container stats...\n")
-		containerInfo.WriteString(fmt.Sprintf("(Found %d containers)", len(m.containerStats)))
 	}
 
-	containerBox := boxStyle.Copy().Width(50).Render(containerInfo.String())
+	return containerInfo.String()
+}
+
+// renderPrometheusPanel renders the promoted metrics (see config/metrics.yaml)
+// as a summary table of sparklines, or — with 'm' toggled on — a single
+// metric's full history, cycled with up/down.
+func (m model) renderPrometheusPanel() string {
+	names := m.promMetricNames()
+	if len(names) == 0 {
+		return boxStyle.Render("üìà Prometheus\n\nWaiting for /metrics samples...\n\n" + m.renderHelp("Stats"))
+	}
+
+	var b strings.Builder
+	b.WriteString("üìà Prometheus\n\n")
 
-	statsBox := lipgloss.JoinHorizontal(lipgloss.Top, systemBox, "  ", containerBox)
-	return statsBox + "\n"
+	if m.promDetailMode {
+		if m.promSelected >= len(names) {
+			m.promSelected = 0
+		}
+		name := names[m.promSelected]
+		h := m.promHistories[name]
+		b.WriteString(fmt.Sprintf("%s (%d/%d, up/down to cycle)\n\n", name, m.promSelected+1, len(names)))
+		b.WriteString(fmt.Sprintf("%s\n", h.Sparkline()))
+		b.WriteString(fmt.Sprintf("latest: %.2f\n", h.Latest()))
+	} else {
+		for _, name := range names {
+			h := m.promHistories[name]
+			b.WriteString(fmt.Sprintf("%-40s %s %.2f\n", name, h.Sparkline(), h.Latest()))
+		}
+	}
+
+	b.WriteString("\n" + m.renderHelp("Stats"))
+	return boxStyle.Render(b.String())
 }
 
 func (m model) renderGraphView() string {
 	graphInfo := "üï∏Ô∏è  Knowledge Graph Statistics\n\n"
 	graphInfo += fmt.Sprintf("Nodes:    %d\n", m.graphStats.nodeCount)
 	graphInfo += fmt.Sprintf("Edges:    %d\n", m.graphStats.edgeCount)
+	graphInfo += fmt.Sprintf("Memory:   %s\n", m.graphStats.memoryUsage)
 	graphInfo += fmt.Sprintf("Last Update: %s\n", m.graphStats.lastUpdate)
 
+	graphInfo += "\n⏱️  Slow Queries:\n"
+	if len(m.graphStats.slowQueries) == 0 {
+		graphInfo += "  (none)\n"
+	} else {
+		for _, q := range m.graphStats.slowQueries {
+			graphInfo += fmt.Sprintf("  [%s] %s (%sms): %s\n", q.Timestamp, q.Command, q.Duration, q.Query)
+		}
+	}
+
 	graphInfo += "\nüìä Entity Types:\n"
 	graphInfo += "  ‚Ä¢ GeographicSoul (State, District, Pincode)\n"
 	graphInfo += "  ‚Ä¢ IdentityLifecycle\n"
@@ -712,7 +1093,7 @@ func (m model) renderDashboardView() string {
 		dashboardInfo += "Frontend: http://localhost:5173\n"
 		dashboardInfo += "Backend API: http://localhost:8001\n\n"
 	} else {
-		dashboardInfo += "Status: " + errorStyle.Render("‚óã Stopped") + "\n"
+		dashboardInfo += "Status: " + errorStyle.Render("○ Stopped") + "\n"
 		dashboardInfo += "Press 'd' to start the dashboard\n\n"
 	}
 
@@ -759,23 +1140,222 @@ func (m model) renderDashboardView() string {
 	return dashboardBox + "\n"
 }
 
+// planNodeName maps a progress.Writer vertex ID to the Plan-view resource
+// name it corresponds to, or "" if the vertex doesn't track an individual
+// service (e.g. the startup preflight "system" vertex).
+func planNodeName(vertexID string) string {
+	switch vertexID {
+	case "falkordb":
+		return "FalkorDB"
+	case "ollama":
+		return "Ollama"
+	case "graphiti-mcp":
+		return "Graphiti MCP"
+	case "processing":
+		return "Atlas Engine"
+	case "dashboard":
+		return "Atlas Dashboard"
+	default:
+		return ""
+	}
+}
+
+// syncPlanStates mirrors the progress.Writer's vertex states into the
+// Plan view's StateStore, marking a failed vertex's descendants as
+// blocked instead of leaving them pending.
+func (m model) syncPlanStates() {
+	for _, v := range m.progressWriter.Snapshot() {
+		name := planNodeName(v.ID)
+		if name == "" {
+			continue
+		}
+		switch v.State {
+		case progress.StateRunning:
+			m.planStates.Set(name, servicegraph.StateCreating)
+		case progress.StateDone:
+			m.planStates.Set(name, servicegraph.StateOK)
+		case progress.StateError:
+			m.planStates.FailAndBlock(m.serviceGraph, name)
+		}
+	}
+}
+
+func planStateGlyph(state servicegraph.State) (string, string) {
+	switch state {
+	case servicegraph.StateCreating, servicegraph.StateUpdating:
+		return "⟳", "214"
+	case servicegraph.StateOK:
+		return "✓", "42"
+	case servicegraph.StateFailed:
+		return "✗", "196"
+	case servicegraph.StateBlocked:
+		return "⊘", "240"
+	default:
+		return "○", "240"
+	}
+}
+
+// renderPlanView renders the service dependency DAG as an indented,
+// topologically ordered tree with live per-node status, the way Pulumi's
+// preview/update renders a resource plan.
+func (m model) renderPlanView() string {
+	order := m.serviceGraph.TopoOrder()
+	states := m.planStates.Snapshot()
+
+	counts := make(map[servicegraph.State]int)
+	for _, state := range states {
+		counts[state]++
+	}
+
+	var b strings.Builder
+	b.WriteString("📐 Resource Plan\n\n")
+	b.WriteString(fmt.Sprintf("%d ok, %d creating, %d blocked, %d failed, %d pending\n\n",
+		counts[servicegraph.StateOK], counts[servicegraph.StateCreating],
+		counts[servicegraph.StateBlocked], counts[servicegraph.StateFailed], counts[servicegraph.StatePending]))
+
+	for _, name := range order {
+		indent := strings.Repeat("  ", m.serviceGraph.Depth(name))
+		glyph, color := planStateGlyph(states[name])
+		style := lipgloss.NewStyle().Foreground(lipgloss.Color(color))
+		b.WriteString(fmt.Sprintf("%s%s %s (%s)\n", indent, style.Render(glyph), name, states[name]))
+	}
+
+	return boxStyle.Render(b.String() + "\n" + m.renderHelp("Plan")) + "\n"
+}
+
+// renderTracesView renders the most recently received OTLP spans as a
+// collapsed waterfall: start time, a duration bar scaled to the slowest
+// span in the window, service, span name, and status.
+func (m model) renderTracesView() string {
+	if len(m.traces) == 0 {
+		return boxStyle.Render("🛰️  Traces\n\nNo spans received yet. Point services at "+
+			"OTEL_EXPORTER_OTLP_ENDPOINT=http://localhost:4317 to see them here.\n\n"+
+			m.renderHelp("Traces")) + "\n"
+	}
+
+	var maxDur time.Duration
+	for _, span := range m.traces {
+		if d := span.End.Sub(span.Start); d > maxDur {
+			maxDur = d
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("🛰️  Traces\n\n")
+	for _, span := range m.traces {
+		dur := span.End.Sub(span.Start)
+		barLen := 0
+		if maxDur > 0 {
+			barLen = int(float64(dur) / float64(maxDur) * 30)
+		}
+		status := span.Status
+		if status == "" {
+			status = "ok"
+		}
+		b.WriteString(fmt.Sprintf("%s  %-16s %-24s %-30s %v (%s)\n",
+			span.Start.Format("15:04:05.000"), span.Service, span.Name, strings.Repeat("█", barLen), dur.Round(time.Millisecond), status))
+	}
+
+	return boxStyle.Render(b.String() + "\n" + m.renderHelp("Traces")) + "\n"
+}
+
+// renderAnalyzeView renders the analyzer's findings grouped by service, the
+// way `oc status` groups a project's problems by the resource they affect.
+func (m model) renderAnalyzeView() string {
+	if len(m.findings) == 0 {
+		return boxStyle.Render("üîç Analyze\n\nNo issues detected.\n\n"+m.renderHelp("Analyze")) + "\n"
+	}
+
+	grouped := make(map[string][]analyzer.Finding)
+	var order []string
+	for _, f := range m.findings {
+		if _, ok := grouped[f.Service]; !ok {
+			order = append(order, f.Service)
+		}
+		grouped[f.Service] = append(grouped[f.Service], f)
+	}
+	sort.Strings(order)
+
+	var b strings.Builder
+	b.WriteString("üîç Analyze\n\n")
+	for _, svc := range order {
+		b.WriteString(svc + ":\n")
+		for _, f := range grouped[svc] {
+			color := "240"
+			switch f.Severity {
+			case analyzer.Warn:
+				color = "214"
+			case analyzer.Error:
+				color = "196"
+			}
+			sevStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(color))
+			b.WriteString(fmt.Sprintf("  %s %s\n", sevStyle.Render(strings.ToUpper(f.Severity.String())), f.Message))
+			if f.Remediation != "" {
+				b.WriteString(fmt.Sprintf("    -> %s\n", f.Remediation))
+			}
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(m.renderHelp("Analyze"))
+
+	return boxStyle.Render(b.String()) + "\n"
+}
+
+func (m model) renderProcessesView() string {
+	if len(m.processStats) == 0 {
+		return boxStyle.Render("üß© Processes\n\nNo process stats yet.\n\n"+m.renderHelp("Processes")) + "\n"
+	}
+
+	var order []string
+	for svc := range m.processStats {
+		order = append(order, svc)
+	}
+	sort.Strings(order)
+
+	var b strings.Builder
+	b.WriteString("üß© Processes\n\n")
+	for _, svc := range order {
+		procs := append([]procstats.Info(nil), m.processStats[svc]...)
+		sort.Slice(procs, func(i, j int) bool { return procs[i].CPUPercent > procs[j].CPUPercent })
+
+		b.WriteString(svc + ":\n")
+		b.WriteString(fmt.Sprintf("  %-8s %-20s %7s %10s %8s %6s %6s\n", "PID", "NAME", "CPU%", "RSS", "THREADS", "FDS", "CONNS"))
+		for _, p := range procs {
+			b.WriteString(fmt.Sprintf("  %-8d %-20s %7s %10s %8d %6d %6d\n",
+				p.PID, p.Name, formatPercent(p.CPUPercent), formatBytes(p.RSS), p.NumThreads, p.NumFDs, p.NumConns))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(m.renderHelp("Processes"))
+
+	return boxStyle.Render(b.String()) + "\n"
+}
+
 func (m model) renderHelp(view string) string {
 	help := ""
 	switch view {
 	case "Services":
-		help = "‚Üë/‚Üì: Navigate | Enter: Toggle (auto-starts deps) | l: Logs | R: Restart | s: Start All | x: Stop All | b: Build | p: Process (auto-deps) | d: Dashboard (auto-deps) | r: Refresh | Tab: Switch View | q: Quit"
+		help = "‚Üë/‚Üì: Navigate | Enter: Toggle (auto-starts deps) | l: Logs | R: Restart | s: Start All | x: Stop All | b: Build | p: Process (auto-deps) | d: Dashboard (auto-deps) | Esc: Cancel startup | r: Refresh | Tab: Switch View | q: Quit"
 	case "Logs":
-		help = "Tab: Switch View | q: Quit"
+		help = "Tab: Switch View | l (Services): Follow | /: Filter (regex) | e: Errors only | p: Pause | q: Quit"
 	case "Stats":
-		help = "Tab: Switch View | r: Refresh | q: Quit"
+		help = "Tab: Switch View | r: Refresh | m: Toggle sparkline detail | f: Cycle output format | q: Quit"
 	case "Graph":
 		help = "Tab: Switch View | r: Refresh | q: Quit"
 	case "Config":
 		help = "Tab: Switch View | q: Quit"
 	case "Pipeline":
-		help = "Tab: Switch View | p: Start Processing | q: Quit"
+		help = "Tab: Switch View | p: Start Processing | f: Cycle output format | q: Quit"
 	case "Dashboard":
 		help = "Tab: Switch View | d: Start Dashboard | r: Refresh | q: Quit"
+	case "Plan":
+		help = "Tab: Switch View | s: Start All | p: Process (auto-deps) | d: Dashboard (auto-deps) | Esc: Cancel startup | q: Quit"
+	case "Traces":
+		help = "Tab: Switch View | q: Quit"
+	case "Analyze":
+		help = "Tab: Switch View | R (Services): Restart selected | b: Rebuild | q: Quit"
+	case "Processes":
+		help = "Tab: Switch View | r: Refresh | q: Quit"
 	}
 	return infoStyle.Render(help)
 }
@@ -784,17 +1364,46 @@ func (m model) renderStatusBar() string {
 	var statusRender string
 	switch m.statusType {
 	case "success":
-		statusRender = successStyle.Render("‚úì " + m.statusMsg)
+		statusRender = successStyle.Render("✓ " + m.statusMsg)
 	case "error":
-		statusRender = errorStyle.Render("‚úó " + m.statusMsg)
-	case "warning":
+		statusRender = errorStyle.Render("✗ " + m.statusMsg)
+	case "warning", "warn":
 		statusRender = warningStyle.Render("‚ö† " + m.statusMsg)
 	default:
 		statusRender = infoStyle.Render(m.statusMsg)
 	}
+	if len(m.findings) > 0 {
+		statusRender += "  " + warningStyle.Render(fmt.Sprintf("‚ö† %d issues", len(m.findings)))
+	}
 	return statusRender
 }
 
+// promMetricNames returns the promoted metric names in a stable (sorted)
+// order, so cycling through them in detail mode doesn't jump around as the
+// underlying map is re-populated on every scrape.
+func (m model) promMetricNames() []string {
+	names := make([]string, 0, len(m.promHistories))
+	for name := range m.promHistories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// promCycle moves the detail-mode selection by delta, wrapping around the
+// promoted metric list.
+func (m model) promCycle(delta int) int {
+	names := m.promMetricNames()
+	if len(names) == 0 {
+		return 0
+	}
+	next := (m.promSelected + delta) % len(names)
+	if next < 0 {
+		next += len(names)
+	}
+	return next
+}
+
 func (m model) getMaxSelection() int {
 	switch m.currentView {
 	case viewServices:
@@ -809,20 +1418,20 @@ func (m model) getMaxSelection() int {
 func (m model) handleAction() tea.Cmd {
 	if m.currentView == viewServices {
 		if m.selected < len(m.services) {
-			return toggleService(m.services[m.selected].name)
+			return toggleService(m.services[m.selected].name, m.probeConfig)
 		} else {
 			actionIdx := m.selected - len(m.services)
 			switch actionIdx {
 			case 0:
-				return startServices()
+				return startServices(m.probeConfig)
 			case 1:
 				return stopServices()
 			case 2:
-				return buildServices()
+				return buildServices(m.progressWriter)
 			case 3:
-				return startProcessing()
+				return startProcessing(context.Background(), m.progressWriter, m.probeConfig)
 			case 4:
-				return startDashboard()
+				return startDashboard(m.progressWriter, m.probeConfig)
 			}
 		}
 	}
@@ -833,13 +1442,7 @@ func (m model) handleAction() tea.Cmd {
 
 func checkServices() tea.Cmd {
 	return func() tea.Msg {
-		services := []service{
-			{name: "Ollama", port: "11434", url: "http://localhost:11434"},
-			{name: "FalkorDB", port: "6379", url: "http://localhost:3000"},
-			{name: "Graphiti MCP", port: "8000", url: "http://localhost:8000"},
-			{name: "Atlas Engine", port: "", url: ""},
-			{name: "Atlas Dashboard", port: "5173 (UI) / 8001 (API)", url: "http://localhost:5173"},
-		}
+		services := defaultServices()
 
 		containerMap := map[string]string{
 			"Ollama":          "ollama",
@@ -873,60 +1476,19 @@ type containerInfo struct {
 	health string
 }
 
+// getContainerInfo looks up a container by name via the Docker Engine API,
+// giving us the real structured State.Health.Status and uptime computed
+// from State.StartedAt instead of parsing `docker ps --format` strings.
 func getContainerInfo(containerName string) (bool, containerInfo) {
-	// Check if running
-	cmd := exec.Command("docker", "ps", "--format", "{{.Names}}")
-	output, err := cmd.Output()
-	if err != nil {
+	info, ok, err := dockerapi.Inspect(context.Background(), containerName)
+	if err != nil || !ok {
 		return false, containerInfo{}
 	}
-
-	running := strings.Contains(string(output), containerName)
-	if !running {
-		return false, containerInfo{}
-	}
-
-	info := containerInfo{health: "unknown"}
-
-	// Get container ID
-	cmd = exec.Command("docker", "ps", "--filter", fmt.Sprintf("name=%s", containerName), "--format", "{{.ID}}")
-	output, err = cmd.Output()
-	if err == nil {
-		info.id = strings.TrimSpace(string(output))
-	}
-
-	// Get uptime
-	cmd = exec.Command("docker", "ps", "--filter", fmt.Sprintf("name=%s", containerName), "--format", "{{.Status}}")
-	output, err = cmd.Output()
-	if err == nil {
-		status := strings.TrimSpace(string(output))
-		if strings.Contains(status, "Up") {
-			parts := strings.Fields(status)
-			if len(parts) >= 3 {
-				info.uptime = strings.Join(parts[1:len(parts)-1], " ")
-			}
-		}
-		if strings.Contains(status, "health") {
-			if strings.Contains(status, "healthy") {
-				info.health = "healthy"
-			} else {
-				info.health = "unhealthy"
-			}
-		} else {
-			info.health = "running"
-		}
-	}
-
-	return true, info
+	return true, containerInfo{id: info.ID, uptime: info.Uptime, health: info.Health}
 }
 
 func isContainerRunning(containerName string) bool {
-	cmd := exec.Command("docker", "ps", "--format", "{{.Names}}")
-	output, err := cmd.Output()
-	if err != nil {
-		return false
-	}
-	return strings.Contains(string(output), containerName)
+	return dockerapi.IsRunning(context.Background(), containerName)
 }
 
 func getContainerName(serviceName string) string {
@@ -940,279 +1502,168 @@ func getContainerName(serviceName string) string {
 	return containerMap[serviceName]
 }
 
-func startServices() tea.Cmd {
+func startServices(probeCfg *probe.Config) tea.Cmd {
 	return func() tea.Msg {
-		// Start core services first (FalkorDB and Ollama)
+		msg, err := lifecycle.Up(context.Background(), []string{"falkordb", "ollama"}, nil)
+		if err != nil {
+			return statusMsg{message: err.Error(), msgType: "error"}
+		}
 		return tea.Batch(
-			startServiceWithDeps("falkordb", []string{}),
-			startServiceWithDeps("ollama", []string{}),
-			func() tea.Msg {
-				return statusMsg{message: "Starting core services (FalkorDB, Ollama)...", msgType: "info"}
-			},
+			checkServices(),
+			func() tea.Msg { return statusMsg{message: msg, msgType: "info"} },
 		)
 	}
 }
 
 // startServicesWithProgress starts services with progress tracking
-func startServicesWithProgress() tea.Cmd {
-	// Use buffered channel to hold all progress messages
-	startupProgressChan = make(chan tea.Msg, 100)
-
-	go func() {
-		// First, check if Docker is running
-		startupProgressChan <- progressMsg{
-			progress: startupProgress{
-				serviceName:    "System",
-				step:           "starting",
-				progress:       0.0,
-				elapsedTime:    0,
-				estimatedTotal: time.Duration(3) * 25 * time.Second,
-				logs:           []string{"Checking Docker status..."},
-			},
-		}
-
-		// Check Docker
-		cmd := exec.Command("docker", "ps")
-		if err := cmd.Run(); err != nil {
-			startupProgressChan <- progressMsg{
-				progress: startupProgress{
-					serviceName:    "System",
-					step:           "failed",
-					progress:       0.0,
-					elapsedTime:    0,
-					estimatedTotal: 0,
-					logs:           []string{"‚ùå Docker is not running! Please start Docker Desktop first."},
-				},
-			}
-			close(startupProgressChan)
-			return
-		}
-
-		// Check if docker-compose.yml exists
-		if _, err := os.Stat("docker-compose.yml"); os.IsNotExist(err) {
-			startupProgressChan <- progressMsg{
-				progress: startupProgress{
-					serviceName:    "System",
-					step:           "failed",
-					progress:       0.0,
-					elapsedTime:    0,
-					estimatedTotal: 0,
-					logs:           []string{"‚ùå docker-compose.yml not found in current directory!"},
-				},
-			}
-			close(startupProgressChan)
-			return
-		}
-
-		services := []string{"falkordb", "ollama", "graphiti-mcp"}
-		total := len(services)
-		overallStartTime := time.Now()
-
-		for i, serviceName := range services {
-			displayName := getServiceDisplayName(serviceName)
-			serviceStartTime := time.Now()
-
-			// Initial progress - start at a small value, not 0
-			baseProgress := float64(i) / float64(total)
-			startupProgressChan <- progressMsg{
-				progress: startupProgress{
-					serviceName:    displayName,
-					step:           "starting",
-					progress:       baseProgress + 0.05, // Start at 5% into this service
-					elapsedTime:    time.Since(overallStartTime),
-					estimatedTotal: time.Duration(total) * 30 * time.Second,
-					logs:           []string{fmt.Sprintf("[%s] Starting service...", displayName)},
-				},
-			}
+// startServicesWithProgress kicks off performStartup in the background and
+// routes its vertex events into pw; the Bubble Tea model already polls
+// pw.Done() on every tickMsg, so there's no message to return here. ctx is
+// the cancelable context wired to the "esc" key on the startup progress
+// screen, so a user can bail out of a stuck health check.
+func startServicesWithProgress(ctx context.Context, pw *progress.Writer, probeCfg *probe.Config) tea.Cmd {
+	return func() tea.Msg {
+		services := lifecycle.StartupOrder([]string{"falkordb", "ollama", "graphiti-mcp"})
+		go performStartup(ctx, pw, services, probeCfg)
+		return nil
+	}
+}
 
-			// Check if already running
-			if isContainerRunning(serviceName) {
-				startupProgressChan <- progressMsg{
-					progress: startupProgress{
-						serviceName:    displayName,
-						step:           "completed",
-						progress:       float64(i+1) / float64(total),
-						elapsedTime:    time.Since(overallStartTime),
-						estimatedTotal: time.Duration(total) * 30 * time.Second,
-						logs:           []string{fmt.Sprintf("[%s] ‚úì Already running", displayName)},
-					},
-				}
-				continue
-			}
+// performStartup brings up each of the given compose services in order,
+// reporting its progress as a BuildKit-style vertex per service (plus a
+// "system" vertex for the Docker/compose-file preflight checks).
+func performStartup(ctx context.Context, pw *progress.Writer, services []string, probeCfg *probe.Config) error {
+	pw.Vertex("system", "System")
+	pw.VertexStatus("system", progress.StateRunning)
+	pw.VertexLog("system", "Checking Docker Engine API connectivity...")
 
-			// Start the service
-			logs := []string{fmt.Sprintf("[%s] Running: docker compose up -d %s", displayName, serviceName)}
-			cmd := exec.Command("docker", "compose", "up", "-d", serviceName)
-			output, err := cmd.CombinedOutput()
-
-			if err != nil {
-				// Try docker-compose fallback
-				logs = append(logs, fmt.Sprintf("[%s] docker compose failed, trying docker-compose...", displayName))
-				cmd = exec.Command("docker-compose", "up", "-d", serviceName)
-				output2, err2 := cmd.CombinedOutput()
-				if err2 != nil {
-					logs = append(logs, fmt.Sprintf("[%s] ‚ùå Error starting service: %v", displayName, err2))
-					if len(output2) > 0 {
-						outputStr := strings.TrimSpace(string(output2))
-						// Show more of the error
-						if len(outputStr) > 300 {
-							outputStr = outputStr[:300] + "..."
-						}
-						logs = append(logs, fmt.Sprintf("[%s] Output: %s", displayName, outputStr))
-					}
-					// Also check what the actual error was
-					if exitErr, ok := err2.(*exec.ExitError); ok {
-						logs = append(logs, fmt.Sprintf("[%s] Exit code: %d", displayName, exitErr.ExitCode()))
-					}
-					startupProgressChan <- progressMsg{
-						progress: startupProgress{
-							serviceName:    displayName,
-							step:           "failed",
-							progress:       float64(i+1) / float64(total),
-							elapsedTime:    time.Since(overallStartTime),
-							estimatedTotal: time.Duration(total) * 30 * time.Second,
-							logs:           logs,
-						},
-					}
-					continue
-				}
-				output = output2
-				logs = append(logs, fmt.Sprintf("[%s] ‚úì Started with docker-compose", displayName))
-			} else {
-				logs = append(logs, fmt.Sprintf("[%s] ‚úì Started with docker compose", displayName))
-			}
+	cli, err := dockerapi.Client()
+	if err != nil {
+		err = fmt.Errorf("Docker is not running! Please start Docker Desktop first")
+		pw.VertexDone("system", err)
+		return err
+	}
+	if _, err := cli.Ping(ctx); err != nil {
+		err = fmt.Errorf("Docker is not running! Please start Docker Desktop first")
+		pw.VertexDone("system", err)
+		return err
+	}
 
-			if len(output) > 0 {
-				outputStr := strings.TrimSpace(string(output))
-				// Show more output for debugging
-				if len(outputStr) > 200 {
-					outputStr = outputStr[:200] + "..."
-				}
-				logs = append(logs, fmt.Sprintf("[%s] %s", displayName, outputStr))
-			}
+	project, err := loadComposeProject()
+	if err != nil {
+		pw.VertexDone("system", err)
+		return err
+	}
+	pw.VertexDone("system", nil)
 
-			// Give container a moment to start
-			time.Sleep(2 * time.Second)
+	for _, serviceName := range services {
+		displayName := getServiceDisplayName(serviceName)
+		vertexID := serviceName
+		serviceStartTime := time.Now()
 
-			// Check if container is running
-			running, info := getContainerInfo(serviceName)
-			if !running {
-				logs = append(logs, fmt.Sprintf("[%s] ‚ö† Container not found after start command", displayName))
-				// Check Docker logs
-				cmd = exec.Command("docker", "logs", "--tail", "10", serviceName)
-				if logOutput, logErr := cmd.CombinedOutput(); logErr == nil && len(logOutput) > 0 {
-					logStr := strings.TrimSpace(string(logOutput))
-					if len(logStr) > 200 {
-						logStr = logStr[:200] + "..."
-					}
-					logs = append(logs, fmt.Sprintf("[%s] Container logs: %s", displayName, logStr))
+		pw.Vertex(vertexID, displayName)
+		pw.VertexStatus(vertexID, progress.StateRunning)
+		pw.VertexLog(vertexID, "Starting service...")
+
+		if isContainerRunning(serviceName) {
+			pw.VertexLog(vertexID, "Already running")
+			pw.VertexDone(vertexID, nil)
+			continue
+		}
+
+		pw.VertexLog(vertexID, fmt.Sprintf("Creating and starting %s via the Engine API", serviceName))
+		if err := dockerapi.Up(ctx, project, []string{serviceName}, func(line string) { pw.VertexLog(vertexID, line) }); err != nil {
+			pw.VertexLog(vertexID, fmt.Sprintf("Error starting service: %v", err))
+			pw.VertexDone(vertexID, err)
+			continue
+		}
+
+		// Give container a moment to start
+		select {
+		case <-ctx.Done():
+			pw.VertexDone(vertexID, ctx.Err())
+			return ctx.Err()
+		case <-time.After(2 * time.Second):
+		}
+
+		running, info := getContainerInfo(serviceName)
+		if !running {
+			pw.VertexLog(vertexID, "Container not found after start command")
+			if logLines, logErr := dockerapi.Logs(ctx, serviceName, 10); logErr == nil && len(logLines) > 0 {
+				logStr := strings.Join(logLines, " | ")
+				if len(logStr) > 200 {
+					logStr = logStr[:200] + "..."
 				}
-			} else {
-				logs = append(logs, fmt.Sprintf("[%s] Container is running (health: %s)", displayName, info.health))
+				pw.VertexLog(vertexID, fmt.Sprintf("Container logs: %s", logStr))
 			}
+		} else {
+			pw.VertexLog(vertexID, fmt.Sprintf("Container is running (health: %s)", info.health))
+		}
 
-			// Waiting for health
-			startupProgressChan <- progressMsg{
-				progress: startupProgress{
-					serviceName:    displayName,
-					step:           "waiting_health",
-					progress:       (float64(i) + 0.6) / float64(total),
-					elapsedTime:    time.Since(overallStartTime),
-					estimatedTotal: time.Duration(total) * 30 * time.Second,
-					logs:           logs,
-				},
-			}
+		pw.VertexLog(vertexID, "Waiting for readiness probe...")
 
-			// Wait for health check - Ollama needs more time on first start
-			healthCheckStart := time.Now()
-			maxWait := 120 * time.Second // Increased timeout for Ollama especially
-			if serviceName == "ollama" {
-				maxWait = 180 * time.Second // Ollama can take up to 3 minutes on first start
+		// Wait for health check - Ollama needs more time on first start
+		maxWait := 120 * time.Second
+		if serviceName == "ollama" {
+			maxWait = 180 * time.Second
+		}
+		checkInterval := 3 * time.Second
+		successThreshold := 1
+		if spec, ok := probeCfg.Services[serviceName]; ok {
+			if spec.SuccessThreshold > 0 {
+				successThreshold = spec.SuccessThreshold
 			}
-			checkInterval := 3 * time.Second
-			healthCheckDone := false
-			lastUpdateTime := time.Now()
-
-			for time.Since(healthCheckStart) < maxWait && !healthCheckDone {
-				running, info = getContainerInfo(serviceName)
-				if running {
-					if info.health == "healthy" || info.health == "running" {
-						logs = append(logs, fmt.Sprintf("[%s] ‚úì Healthy (health: %s, took: %v)", displayName, info.health, time.Since(serviceStartTime).Round(time.Second)))
-						healthCheckDone = true
-						break
-					}
-					// Update progress based on elapsed time
-					elapsed := time.Since(healthCheckStart)
-					progressIncrement := elapsed.Seconds() / maxWait.Seconds() * 0.3 // 30% of service progress for health check
-					currentProgress := (float64(i) + 0.6 + progressIncrement) / float64(total)
-					if currentProgress > float64(i+1)/float64(total) {
-						currentProgress = float64(i+1) / float64(total)
-					}
-
-					// Send updates every 3 seconds
-					if time.Since(lastUpdateTime) >= 3*time.Second {
-						startupProgressChan <- progressMsg{
-							progress: startupProgress{
-								serviceName:    displayName,
-								step:           "waiting_health",
-								progress:       currentProgress,
-								elapsedTime:    time.Since(overallStartTime),
-								estimatedTotal: time.Duration(total) * 30 * time.Second,
-								logs:           []string{fmt.Sprintf("[%s] Waiting for health check... (elapsed: %v, health: %s)", displayName, elapsed.Round(time.Second), info.health)},
-							},
-						}
-						lastUpdateTime = time.Now()
-					}
-				} else {
-					logs = append(logs, fmt.Sprintf("[%s] ‚ö† Container not running yet...", displayName))
+			if spec.PeriodSeconds > 0 {
+				checkInterval = time.Duration(spec.PeriodSeconds) * time.Second
+			}
+			if spec.InitialDelaySeconds > 0 {
+				select {
+				case <-ctx.Done():
+					pw.VertexDone(vertexID, ctx.Err())
+					return ctx.Err()
+				case <-time.After(time.Duration(spec.InitialDelaySeconds) * time.Second):
 				}
-				time.Sleep(checkInterval)
 			}
-
-			// Final status
-			running, info = getContainerInfo(serviceName)
-			if !healthCheckDone {
-				if running {
-					logs = append(logs, fmt.Sprintf("[%s] ‚ö† Started (health: %s, wait time exceeded)", displayName, info.health))
-				} else {
-					logs = append(logs, fmt.Sprintf("[%s] ‚ö† May not be fully started", displayName))
+		}
+		healthCheckStart := time.Now()
+		healthCheckDone := false
+		consecutivePasses := 0
+
+		for time.Since(healthCheckStart) < maxWait && !healthCheckDone {
+			if runProbes(ctx, serviceName, probeCfg) {
+				consecutivePasses++
+				if consecutivePasses >= successThreshold {
+					pw.VertexLog(vertexID, fmt.Sprintf("Ready (took: %v)", time.Since(serviceStartTime).Round(time.Second)))
+					healthCheckDone = true
+					break
+				}
+			} else {
+				consecutivePasses = 0
+				if !isContainerRunning(serviceName) {
+					pw.VertexLog(vertexID, "Container not running yet...")
 				}
 			}
-
-			// Completed
-			startupProgressChan <- progressMsg{
-				progress: startupProgress{
-					serviceName:    displayName,
-					step:           "completed",
-					progress:       float64(i+1) / float64(total),
-					elapsedTime:    time.Since(overallStartTime),
-					estimatedTotal: time.Duration(total) * 25 * time.Second,
-					logs:           logs,
-				},
+			select {
+			case <-ctx.Done():
+				pw.VertexDone(vertexID, ctx.Err())
+				return ctx.Err()
+			case <-time.After(checkInterval):
 			}
 		}
-		close(startupProgressChan)
-	}()
-
-	return readNextStartupProgress()
-}
-
-// Global channel for startup progress - needed for command chaining
-var startupProgressChan chan tea.Msg
 
-// readNextStartupProgress reads the next progress message from channel
-func readNextStartupProgress() tea.Cmd {
-	if startupProgressChan == nil {
-		return nil
-	}
-	return func() tea.Msg {
-		// Block until we get a message or channel is closed
-		msg, ok := <-startupProgressChan
-		if !ok {
-			return nil
+		running, info = getContainerInfo(serviceName)
+		if !healthCheckDone {
+			if running {
+				pw.VertexLog(vertexID, fmt.Sprintf("Started (health: %s, wait time exceeded)", info.health))
+			} else {
+				pw.VertexLog(vertexID, "May not be fully started")
+			}
 		}
-		return msg
+
+		pw.VertexDone(vertexID, nil)
 	}
+
+	return nil
 }
 
 func getServiceDisplayName(containerName string) string {
@@ -1227,219 +1678,77 @@ func getServiceDisplayName(containerName string) string {
 	return containerName
 }
 
-// startServiceWithDeps starts a service and its dependencies
-func startServiceWithDeps(serviceName string, deps []string) tea.Cmd {
-	return func() tea.Msg {
-		// Start dependencies first
-		for _, dep := range deps {
-			if !isContainerRunning(dep) {
-				cmd := exec.Command("docker", "compose", "up", "-d", dep)
-				if err := cmd.Run(); err != nil {
-					cmd = exec.Command("docker-compose", "up", "-d", dep)
-					if err := cmd.Run(); err != nil {
-						return statusMsg{message: fmt.Sprintf("Failed to start dependency %s: %v", dep, err), msgType: "error"}
-					}
-				}
-				// Wait for health check
-				waitForHealth(dep, 30*time.Second)
-			}
-		}
-
-		// Start the service
-		if !isContainerRunning(serviceName) {
-			cmd := exec.Command("docker", "compose", "up", "-d", serviceName)
-			if err := cmd.Run(); err != nil {
-				cmd = exec.Command("docker-compose", "up", "-d", serviceName)
-				if err := cmd.Run(); err != nil {
-					return statusMsg{message: fmt.Sprintf("Failed to start %s: %v", serviceName, err), msgType: "error"}
-				}
-			}
-		}
-
-		time.Sleep(1 * time.Second)
-		return checkServices()
+// openBrowser opens url in the platform's default browser, used after the
+// dashboard starts; it's fire-and-forget since a failure here shouldn't
+// fail the start itself.
+func openBrowser(url string) {
+	var openCmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		openCmd = exec.Command("open", url)
+	case "linux":
+		openCmd = exec.Command("xdg-open", url)
+	case "windows":
+		openCmd = exec.Command("cmd", "/c", "start", url)
 	}
-}
-
-// waitForHealth waits for a container to become healthy
-func waitForHealth(containerName string, timeout time.Duration) {
-	deadline := time.Now().Add(timeout)
-	for time.Now().Before(deadline) {
-		if running, info := getContainerInfo(containerName); running && info.health == "healthy" {
-			return
-		}
-		time.Sleep(2 * time.Second)
+	if openCmd != nil {
+		openCmd.Run()
 	}
 }
 
 func stopServices() tea.Cmd {
 	return func() tea.Msg {
-		cmd := exec.Command("docker", "compose", "down")
-		if err := cmd.Run(); err != nil {
-			cmd = exec.Command("docker-compose", "down")
-			if err := cmd.Run(); err != nil {
-				return statusMsg{message: fmt.Sprintf("Failed to stop services: %v", err), msgType: "error"}
-			}
+		msg, err := lifecycle.Down(context.Background(), nil)
+		if err != nil {
+			return statusMsg{message: err.Error(), msgType: "error"}
 		}
-
-		time.Sleep(1 * time.Second)
 		return tea.Batch(
 			checkServices(),
-			func() tea.Msg { return statusMsg{message: "Services stopped", msgType: "success"} },
+			func() tea.Msg { return statusMsg{message: msg, msgType: "success"} },
 		)
 	}
 }
 
-func buildServices() tea.Cmd {
+func buildServices(pw *progress.Writer) tea.Cmd {
 	return func() tea.Msg {
-		cmd := exec.Command("docker", "compose", "build")
-		if err := cmd.Run(); err != nil {
-			cmd = exec.Command("docker-compose", "build")
-			if err := cmd.Run(); err != nil {
-				return statusMsg{message: fmt.Sprintf("Failed to build services: %v", err), msgType: "error"}
-			}
-		}
+		pw.Vertex("build", "Build & Start")
+		pw.VertexStatus("build", progress.StateRunning)
 
-		cmd = exec.Command("docker", "compose", "up", "-d")
-		if err := cmd.Run(); err != nil {
-			cmd = exec.Command("docker-compose", "up", "-d")
-			if err := cmd.Run(); err != nil {
-				return statusMsg{message: fmt.Sprintf("Failed to start services: %v", err), msgType: "error"}
-			}
+		msg, err := lifecycle.Build(context.Background(), func(line string) { pw.VertexLog("build", line) })
+		pw.VertexDone("build", err)
+		if err != nil {
+			return statusMsg{message: err.Error(), msgType: "error"}
 		}
-
-		time.Sleep(3 * time.Second)
 		return tea.Batch(
 			checkServices(),
-			func() tea.Msg { return statusMsg{message: "Services built and started", msgType: "success"} },
+			func() tea.Msg { return statusMsg{message: msg, msgType: "success"} },
 		)
 	}
 }
 
-func toggleService(name string) tea.Cmd {
+func toggleService(name string, probeCfg *probe.Config) tea.Cmd {
 	return func() tea.Msg {
 		containerName := getContainerName(name)
 		if containerName == "" {
 			return statusMsg{message: fmt.Sprintf("Unknown service: %s", name), msgType: "error"}
 		}
 
-		var cmd *exec.Cmd
-		if isContainerRunning(containerName) {
-			// Stop service
-			cmd = exec.Command("docker", "stop", containerName)
-			if err := cmd.Run(); err != nil {
-				return statusMsg{message: fmt.Sprintf("Failed to stop %s: %v", name, err), msgType: "error"}
-			}
-			time.Sleep(1 * time.Second)
-			return tea.Batch(
-				checkServices(),
-				func() tea.Msg { return statusMsg{message: fmt.Sprintf("%s stopped", name), msgType: "success"} },
-			)
-		} else {
-			// Start service with dependencies
-			return startServiceIntelligent(name)
-		}
-	}
-}
-
-// startServiceIntelligent starts a service and its dependencies automatically
-func startServiceIntelligent(serviceName string) tea.Cmd {
-	return func() tea.Msg {
-		containerName := getContainerName(serviceName)
-		if containerName == "" {
-			return statusMsg{message: fmt.Sprintf("Unknown service: %s", serviceName), msgType: "error"}
-		}
-
-		// Define service dependencies
-		dependencies := map[string][]string{
-			"graphiti-mcp":    {"falkordb", "ollama"},
-			"atlas-engine":    {"graphiti-mcp", "falkordb"},
-			"atlas-dashboard": {"graphiti-mcp", "falkordb"},
-		}
-
-		// Start dependencies first
-		if deps, ok := dependencies[containerName]; ok {
-			for _, dep := range deps {
-				if !isContainerRunning(dep) {
-					cmd := exec.Command("docker", "compose", "up", "-d", dep)
-					if err := cmd.Run(); err != nil {
-						cmd = exec.Command("docker-compose", "up", "-d", dep)
-						if err := cmd.Run(); err != nil {
-							return statusMsg{message: fmt.Sprintf("Failed to start dependency %s: %v", dep, err), msgType: "error"}
-						}
-					}
-					// Wait for health check (with timeout)
-					waitForHealth(dep, 60*time.Second)
-				}
-			}
-		}
-
-		// Start the service
-		var cmd *exec.Cmd
-		if containerName == "atlas-engine" {
-			cmd = exec.Command("docker", "compose", "--profile", "processing", "up", "-d", containerName)
-		} else if containerName == "atlas-dashboard" {
-			cmd = exec.Command("docker", "compose", "--profile", "dashboard", "up", "-d", containerName)
-		} else {
-			cmd = exec.Command("docker", "compose", "up", "-d", containerName)
-		}
-
-		err := cmd.Run()
+		msg, err := lifecycle.Toggle(context.Background(), containerName, probeCfg, nil)
 		if err != nil {
-			// Try docker-compose fallback
-			if containerName == "atlas-engine" {
-				cmd = exec.Command("docker-compose", "--profile", "processing", "up", "-d", containerName)
-			} else if containerName == "atlas-dashboard" {
-				cmd = exec.Command("docker-compose", "--profile", "dashboard", "up", "-d", containerName)
-			} else {
-				cmd = exec.Command("docker-compose", "up", "-d", containerName)
-			}
-			output, err2 := cmd.CombinedOutput()
-			if err2 != nil {
-				errorMsg := fmt.Sprintf("Failed to start %s: %v", serviceName, err2)
-				if len(output) > 0 {
-					outputStr := strings.TrimSpace(string(output))
-					if len(outputStr) > 200 {
-						outputStr = outputStr[:200] + "..."
-					}
-					errorMsg += fmt.Sprintf("\nOutput: %s", outputStr)
-				}
-				return statusMsg{message: errorMsg, msgType: "error"}
-			}
+			return statusMsg{message: err.Error(), msgType: "error"}
 		}
 
-		time.Sleep(2 * time.Second)
-
-		// Special handling for dashboard
-		if containerName == "atlas-dashboard" {
-			// Open browser
+		if containerName == "atlas-dashboard" && isContainerRunning(containerName) {
 			go func() {
 				time.Sleep(3 * time.Second)
-				url := "http://localhost:5173"
-				var openCmd *exec.Cmd
-				switch runtime.GOOS {
-				case "darwin":
-					openCmd = exec.Command("open", url)
-				case "linux":
-					openCmd = exec.Command("xdg-open", url)
-				case "windows":
-					openCmd = exec.Command("cmd", "/c", "start", url)
-				}
-				if openCmd != nil {
-					openCmd.Run()
-				}
+				openBrowser("http://localhost:5173")
 			}()
-			return tea.Batch(
-				checkServices(),
-				func() tea.Msg {
-					return statusMsg{message: fmt.Sprintf("%s started at http://localhost:5173 (API: http://localhost:8001)", serviceName), msgType: "success"}
-				},
-			)
+			msg = fmt.Sprintf("%s started at http://localhost:5173 (API: http://localhost:8001)", name)
 		}
 
 		return tea.Batch(
 			checkServices(),
-			func() tea.Msg { return statusMsg{message: fmt.Sprintf("%s started", serviceName), msgType: "success"} },
+			func() tea.Msg { return statusMsg{message: msg, msgType: "success"} },
 		)
 	}
 }
@@ -1451,135 +1760,57 @@ func restartService(name string) tea.Cmd {
 			return statusMsg{message: fmt.Sprintf("Unknown service: %s", name), msgType: "error"}
 		}
 
-		cmd := exec.Command("docker", "restart", containerName)
-		if err := cmd.Run(); err != nil {
-			return statusMsg{message: fmt.Sprintf("Failed to restart %s: %v", name, err), msgType: "error"}
+		msg, err := lifecycle.Restart(context.Background(), containerName)
+		if err != nil {
+			return statusMsg{message: err.Error(), msgType: "error"}
 		}
 
 		time.Sleep(2 * time.Second)
 		return tea.Batch(
 			checkServices(),
-			func() tea.Msg { return statusMsg{message: fmt.Sprintf("%s restarted", name), msgType: "success"} },
+			func() tea.Msg { return statusMsg{message: msg, msgType: "success"} },
 		)
 	}
 }
 
-func startProcessing() tea.Cmd {
+// startProcessing starts the Atlas Engine processing pipeline. ctx is the
+// cancelable context wired to the "esc" key on the startup progress screen.
+func startProcessing(ctx context.Context, pw *progress.Writer, probeCfg *probe.Config) tea.Cmd {
 	return func() tea.Msg {
-		// Ensure dependencies are running
-		if !isContainerRunning("graphiti-mcp") || !isContainerRunning("falkordb") {
-			return tea.Batch(
-				startServiceIntelligent("Graphiti MCP"),
-				func() tea.Msg { return statusMsg{message: "Starting dependencies for processing...", msgType: "info"} },
-			)
-		}
+		pw.Vertex("processing", "Start Processing")
+		pw.VertexStatus("processing", progress.StateRunning)
 
-		// Start processing with profile
-		cmd := exec.Command("docker", "compose", "--profile", "processing", "up", "-d", "atlas-engine")
-		err := cmd.Run()
+		msg, err := lifecycle.StartProcessing(ctx, probeCfg, func(line string) { pw.VertexLog("processing", line) })
+		pw.VertexDone("processing", err)
 		if err != nil {
-			// Try docker-compose fallback
-			cmd = exec.Command("docker-compose", "--profile", "processing", "up", "-d", "atlas-engine")
-			output, err2 := cmd.CombinedOutput()
-			if err2 != nil {
-				errorMsg := fmt.Sprintf("Failed to start Atlas Engine: %v", err2)
-				if len(output) > 0 {
-					outputStr := strings.TrimSpace(string(output))
-					if len(outputStr) > 300 {
-						outputStr = outputStr[:300] + "..."
-					}
-					errorMsg += fmt.Sprintf("\nOutput: %s", outputStr)
-				}
-				return statusMsg{message: errorMsg, msgType: "error"}
-			}
-		}
-
-		// Wait a bit and verify container started
-		time.Sleep(3 * time.Second)
-
-		// Check if container is actually running
-		if !isContainerRunning("atlas-engine") {
-			// Try to get logs to see what went wrong
-			cmd = exec.Command("docker", "compose", "--profile", "processing", "logs", "--tail", "30", "atlas-engine")
-			logOutput, _ := cmd.CombinedOutput()
-			logStr := strings.TrimSpace(string(logOutput))
-			if len(logStr) > 400 {
-				logStr = logStr[:400] + "..."
-			}
-
-			errorMsg := "Atlas Engine container failed to start"
-			if len(logStr) > 0 {
-				errorMsg += fmt.Sprintf("\nLogs: %s", logStr)
-			} else {
-				errorMsg += "\n(No logs available - container may not have been created)"
-			}
-
-			return statusMsg{message: errorMsg, msgType: "error"}
+			return statusMsg{message: err.Error(), msgType: "error"}
 		}
-
 		return tea.Batch(
 			checkServices(),
-			func() tea.Msg {
-				return statusMsg{message: "Processing pipeline started (check logs for progress)", msgType: "success"}
-			},
+			func() tea.Msg { return statusMsg{message: msg, msgType: "success"} },
 		)
 	}
 }
 
-func startDashboard() tea.Cmd {
+func startDashboard(pw *progress.Writer, probeCfg *probe.Config) tea.Cmd {
 	return func() tea.Msg {
-		// Ensure dependencies are running first
-		if !isContainerRunning("graphiti-mcp") || !isContainerRunning("falkordb") {
-			return tea.Batch(
-				startServiceIntelligent("Graphiti MCP"),
-				func() tea.Msg { return statusMsg{message: "Starting dependencies for dashboard...", msgType: "info"} },
-			)
-		}
+		pw.Vertex("dashboard", "Start Dashboard")
+		pw.VertexStatus("dashboard", progress.StateRunning)
 
-		// Build dashboard if needed (autonomous - Docker handles npm install)
-		cmd := exec.Command("docker", "compose", "--profile", "dashboard", "build", "atlas-dashboard")
-		if _, err := cmd.CombinedOutput(); err != nil {
-			// Try docker-compose if docker compose fails
-			cmd = exec.Command("docker-compose", "--profile", "dashboard", "build", "atlas-dashboard")
-			if output2, err2 := cmd.CombinedOutput(); err2 != nil {
-				return statusMsg{message: fmt.Sprintf("Failed to build dashboard: %v\n%s", err2, string(output2)), msgType: "error"}
-			}
-		}
-
-		// Start the container
-		cmd = exec.Command("docker", "compose", "--profile", "dashboard", "up", "-d", "atlas-dashboard")
-		if err := cmd.Run(); err != nil {
-			cmd = exec.Command("docker-compose", "--profile", "dashboard", "up", "-d", "atlas-dashboard")
-			if err := cmd.Run(); err != nil {
-				return statusMsg{message: fmt.Sprintf("Failed to start dashboard: %v", err), msgType: "error"}
-			}
+		msg, err := lifecycle.StartDashboard(context.Background(), probeCfg, func(line string) { pw.VertexLog("dashboard", line) })
+		pw.VertexDone("dashboard", err)
+		if err != nil {
+			return statusMsg{message: err.Error(), msgType: "error"}
 		}
 
-		time.Sleep(3 * time.Second)
-
-		// Try to open browser (platform-independent)
 		go func() {
-			time.Sleep(2 * time.Second) // Give services a moment to start
-			url := "http://localhost:5173"
-			var openCmd *exec.Cmd
-			switch runtime.GOOS {
-			case "darwin":
-				openCmd = exec.Command("open", url)
-			case "linux":
-				openCmd = exec.Command("xdg-open", url)
-			case "windows":
-				openCmd = exec.Command("cmd", "/c", "start", url)
-			}
-			if openCmd != nil {
-				openCmd.Run()
-			}
+			time.Sleep(2 * time.Second)
+			openBrowser("http://localhost:5173")
 		}()
 
 		return tea.Batch(
 			checkServices(),
-			func() tea.Msg {
-				return statusMsg{message: "Dashboard started at http://localhost:5173 (API: http://localhost:8001)", msgType: "success"}
-			},
+			func() tea.Msg { return statusMsg{message: msg, msgType: "success"} },
 		)
 	}
 }
@@ -1602,12 +1833,12 @@ func installDashboardDeps() tea.Cmd {
 func buildDashboard() tea.Cmd {
 	return func() tea.Msg {
 		// Build dashboard (autonomous)
-		cmd := exec.Command("docker", "compose", "--profile", "dashboard", "build", "atlas-dashboard")
-		if _, err := cmd.CombinedOutput(); err != nil {
-			cmd = exec.Command("docker-compose", "--profile", "dashboard", "build", "atlas-dashboard")
-			if output2, err2 := cmd.CombinedOutput(); err2 != nil {
-				return statusMsg{message: fmt.Sprintf("Failed to build dashboard: %v\n%s", err2, string(output2)), msgType: "error"}
-			}
+		project, err := loadComposeProject()
+		if err != nil {
+			return statusMsg{message: fmt.Sprintf("Failed to load docker-compose.yml: %v", err), msgType: "error"}
+		}
+		if err := dockerapi.Build(context.Background(), project, []string{"atlas-dashboard"}, func(string) {}); err != nil {
+			return statusMsg{message: fmt.Sprintf("Failed to build dashboard: %v", err), msgType: "error"}
 		}
 		return statusMsg{message: "Dashboard built successfully", msgType: "success"}
 	}
@@ -1615,53 +1846,60 @@ func buildDashboard() tea.Cmd {
 
 // Logs
 
-type logMsg struct {
-	service string
-	lines   []string
+// otlpLogMsg and otlpSpanMsg wrap events read off the otlpsink channels;
+// handlers in Update re-issue listenOtlpLogs/listenOtlpSpans to keep
+// draining the channel after each one.
+type otlpLogMsg otlpsink.LogEvent
+type otlpSpanMsg otlpsink.SpanEvent
+
+// startOtlpSink starts the embedded OTLP receiver once. A config with
+// Enabled=false is a no-op, leaving the TUI on the docker-logs fallback.
+func startOtlpSink(cfg otlpsink.Config, logs chan otlpsink.LogEvent, spans chan otlpsink.SpanEvent) tea.Cmd {
+	return func() tea.Msg {
+		if err := otlpsink.Start(context.Background(), cfg, logs, spans); err != nil {
+			return statusMsg{message: fmt.Sprintf("OTLP sink disabled: %v", err), msgType: "warning"}
+		}
+		return nil
+	}
+}
+
+// listenOtlpLogs blocks for the next received LogEvent; the Update handler
+// for otlpLogMsg re-issues this command so the channel keeps draining.
+func listenOtlpLogs(ch <-chan otlpsink.LogEvent) tea.Cmd {
+	return func() tea.Msg {
+		return otlpLogMsg(<-ch)
+	}
+}
+
+// listenOtlpSpans blocks for the next received SpanEvent; the Update
+// handler for otlpSpanMsg re-issues this command so the channel keeps
+// draining.
+func listenOtlpSpans(ch <-chan otlpsink.SpanEvent) tea.Cmd {
+	return func() tea.Msg {
+		return otlpSpanMsg(<-ch)
+	}
 }
 
-func fetchLogs(serviceName string) tea.Cmd {
+// startLogFollow resolves serviceName's container and starts following its
+// combined stdout/stderr into buf via logstream.Follow, the streaming
+// replacement for the old one-shot fetchLogs poll. It returns once the
+// stream is open (or surfaces a status message if it couldn't be); ctx
+// governs how long the background follower goroutines keep running, and
+// is cancelled by the "l" key handler when the user switches to a
+// different service's logs.
+func startLogFollow(ctx context.Context, serviceName string, buf *logstream.RingBuffer) tea.Cmd {
 	return func() tea.Msg {
 		containerName := getContainerName(serviceName)
 		if containerName == "" {
-			return logMsg{service: serviceName, lines: []string{"Service not found"}}
-		}
-
-		// First check if container exists
-		cmd := exec.Command("docker", "ps", "-a", "--filter", fmt.Sprintf("name=%s", containerName), "--format", "{{.Names}}")
-		output, err := cmd.Output()
-		if err != nil || len(strings.TrimSpace(string(output))) == 0 {
-			return logMsg{service: serviceName, lines: []string{
-				fmt.Sprintf("Container '%s' not found.", containerName),
-				"",
-				"Troubleshooting:",
-				"1. Check if Docker Desktop is running",
-				"2. Try: docker ps -a",
-				"3. Check if service was started: docker compose ps",
-			}}
-		}
-
-		// Get logs
-		cmd = exec.Command("docker", "logs", "--tail", "50", containerName)
-		output, err = cmd.CombinedOutput()
-		if err != nil {
-			return logMsg{service: serviceName, lines: []string{
-				fmt.Sprintf("Error fetching logs: %v", err),
-				"",
-				"Container might not be running. Try:",
-				fmt.Sprintf("  docker logs %s", containerName),
-			}}
+			return statusMsg{message: fmt.Sprintf("%s: no container mapping", serviceName), msgType: "warning"}
 		}
-
-		lines := strings.Split(string(output), "\n")
-		if len(lines) == 0 || (len(lines) == 1 && lines[0] == "") {
-			return logMsg{service: serviceName, lines: []string{
-				"No logs available yet.",
-				"Container might be starting. Check status with:",
-				fmt.Sprintf("  docker ps -a | grep %s", containerName),
-			}}
+		if _, ok, err := dockerapi.Inspect(ctx, containerName); err != nil || !ok {
+			return statusMsg{message: fmt.Sprintf("container '%s' not found", containerName), msgType: "warning"}
+		}
+		if err := logstream.Follow(ctx, containerName, buf); err != nil {
+			return statusMsg{message: fmt.Sprintf("log follow for %s: %v", serviceName, err), msgType: "warning"}
 		}
-		return logMsg{service: serviceName, lines: lines}
+		return nil
 	}
 }
 
@@ -1701,6 +1939,8 @@ func updateStats() tea.Cmd {
 			diskUsed = diskInfo.Used
 		}
 
+		metrics.SetHostStats(cpuVal, memUsed, "/", diskUsed)
+
 		return systemStats{
 			cpuPercent:  cpuVal,
 			cpuCount:    cpuCount,
@@ -1714,86 +1954,159 @@ func updateStats() tea.Cmd {
 	}
 }
 
-func updateContainerStats() tea.Cmd {
-	return func() tea.Msg {
-		stats := make(map[string]containerStat)
+// containerStatMsg wraps an update read off the containerstats channel; the
+// Update handler for it re-issues listenContainerStats to keep draining the
+// channel, the same pattern otlpLogMsg/otlpSpanMsg use for the OTLP sink.
+type containerStatMsg containerstats.Update
 
-		// Get stats for all containers - use a more reliable format
-		cmd := exec.Command("docker", "stats", "--no-stream", "--format", "{{.Name}}|{{.CPUPerc}}|{{.MemPerc}}|{{.MemUsage}}|{{.Status}}")
-		output, err := cmd.CombinedOutput()
-		if err != nil {
-			// If docker stats fails, return empty stats
-			return stats
+// startContainerStatsCollector starts the Engine API streaming stats/events
+// collector once; per-container updates arrive afterward as
+// containerStatMsg via listenContainerStats rather than a 2s `docker stats`
+// poll.
+func startContainerStatsCollector(updates chan containerstats.Update) tea.Cmd {
+	return func() tea.Msg {
+		if err := containerstats.Start(context.Background(), updates); err != nil {
+			return statusMsg{message: fmt.Sprintf("container stats disabled: %v", err), msgType: "warning"}
 		}
+		return nil
+	}
+}
 
-		outputStr := strings.TrimSpace(string(output))
-		if outputStr == "" {
-			return stats
-		}
+// listenContainerStats blocks for the next Update; the Update handler for
+// containerStatMsg re-issues this command so the channel keeps draining.
+func listenContainerStats(updates chan containerstats.Update) tea.Cmd {
+	return func() tea.Msg {
+		return containerStatMsg(<-updates)
+	}
+}
+
+// nextStatsFormat cycles the Stats/Pipeline views through the formatter
+// presets; a custom template format (set only via --format) falls back to
+// table on the first press rather than getting stuck.
+func nextStatsFormat(current formatter.Format) formatter.Format {
+	switch current {
+	case formatter.TableFormat, "":
+		return formatter.JSONFormat
+	case formatter.JSONFormat:
+		return formatter.JSONStreamFormat
+	default:
+		return formatter.TableFormat
+	}
+}
 
-		lines := strings.Split(outputStr, "\n")
-		for _, line := range lines {
-			line = strings.TrimSpace(line)
-			if line == "" {
+// containerStatContext adapts a containerStat into the formatter's
+// StatsFormatContext, the shape renderStatsView's container list and
+// renderPipelineView both render through.
+func containerStatContext(name string, stat containerStat) formatter.StatsFormatContext {
+	return formatter.StatsFormatContext{
+		Name:        name,
+		CPUPercent:  stat.cpuPercent,
+		MemPercent:  stat.memPercent,
+		MemUsageStr: stat.memUsage,
+		NetRx:       stat.netRx,
+		NetTx:       stat.netTx,
+		BlockRead:   stat.blockRead,
+		BlockWrite:  stat.blockWrite,
+		Pids:        stat.pids,
+		Status:      stat.status,
+	}
+}
+
+// processStatsMsg carries every service's current process subtree, keyed
+// by service display name, for the Processes view.
+type processStatsMsg map[string][]procstats.Info
+
+// updateProcessStats collects the process subtree behind every service's
+// container and reports it as a processStatsMsg. collector caches
+// *process.Process handles across calls so repeat ticks don't re-NewProcess
+// every PID.
+func updateProcessStats(services []service, collector *procstats.Collector) tea.Cmd {
+	return func() tea.Msg {
+		stats := make(map[string][]procstats.Info, len(services))
+		for _, svc := range services {
+			containerName := getContainerName(svc.name)
+			if containerName == "" {
+				continue
+			}
+			procs, err := collector.Collect(context.Background(), containerName)
+			if err != nil || len(procs) == 0 {
 				continue
 			}
+			stats[svc.name] = procs
+		}
+		collector.EndSweep()
+		return processStatsMsg(stats)
+	}
+}
 
-			// Parse pipe-separated format
-			parts := strings.Split(line, "|")
-			if len(parts) >= 5 {
-				name := strings.TrimSpace(parts[0])
-				var cpuPercent, memPercent float64
-
-				// Parse CPU percentage (remove % sign and handle "0.00%" or "N/A")
-				cpuStr := strings.TrimSpace(strings.TrimSuffix(parts[1], "%"))
-				if cpuStr != "N/A" && cpuStr != "--" && cpuStr != "" {
-					_, parseErr := fmt.Sscanf(cpuStr, "%f", &cpuPercent)
-					if parseErr != nil {
-						cpuPercent = 0.0
-					}
-				}
+// Graph Stats
 
-				// Parse Memory percentage (remove % sign and handle "0.00%" or "N/A")
-				memStr := strings.TrimSpace(strings.TrimSuffix(parts[2], "%"))
-				if memStr != "N/A" && memStr != "--" && memStr != "" {
-					_, parseErr := fmt.Sscanf(memStr, "%f", &memPercent)
-					if parseErr != nil {
-						memPercent = 0.0
-					}
-				}
+// scrapePromMetrics scrapes every service configured in config/metrics.yaml
+// and reports whatever samples came back; an unreachable target is skipped
+// rather than failing the whole round (see promscrape.ScrapeAll).
+func scrapePromMetrics(cfg *promscrape.Config) tea.Cmd {
+	return func() tea.Msg {
+		return promscrape.ScrapeAll(context.Background(), cfg)
+	}
+}
 
-				memUsage := strings.TrimSpace(parts[3])
-				if memUsage == "" {
-					memUsage = "N/A"
-				}
-				status := strings.TrimSpace(parts[4])
-				if status == "" {
-					status = "unknown"
-				}
+// findingsMsg carries the results of an analyzer.Run pass back to Update.
+type findingsMsg []analyzer.Finding
 
-				stats[name] = containerStat{
-					cpuPercent: cpuPercent,
-					memPercent: memPercent,
-					memUsage:   memUsage,
-					status:     status,
-				}
-			}
+// buildAnalyzerSnapshot copies the subset of model state the analyzer
+// package needs into its own types, so it stays decoupled from main's
+// unexported service/systemStats fields.
+func buildAnalyzerSnapshot(m model) analyzer.Snapshot {
+	services := make(map[string]analyzer.ServiceInfo, len(m.services))
+	for _, svc := range m.services {
+		services[svc.name] = analyzer.ServiceInfo{
+			Name:      svc.name,
+			Status:    svc.status,
+			Health:    svc.health,
+			Port:      svc.port,
+			DependsOn: svc.dependsOn,
+		}
+	}
+	logTails := make(map[string][]string, len(m.logBuffers))
+	for name, buf := range m.logBuffers {
+		for _, l := range buf.Lines() {
+			logTails[name] = append(logTails[name], l.Text)
 		}
+	}
 
-		return stats
+	return analyzer.Snapshot{
+		Services: services,
+		System:   analyzer.SystemInfo{DiskPercent: m.stats.diskPercent},
+		LogTails: logTails,
 	}
 }
 
-// Graph Stats
+// runAnalysis runs the default Check set against the current model state.
+func runAnalysis(m model) tea.Cmd {
+	snapshot := buildAnalyzerSnapshot(m)
+	return func() tea.Msg {
+		return findingsMsg(analyzer.Run(snapshot, analyzer.DefaultChecks()))
+	}
+}
 
-func updateGraphStats() tea.Cmd {
+// updateGraphStats queries FalkorDB directly for the atlas graph's live
+// node/edge counts, memory usage, and slow-query log. collector holds the
+// pooled redis connection graphstats.NewCollector opened at startup. A
+// query failure (FalkorDB not up yet, graph not created yet) degrades to a
+// graphStats carrying the error in lastUpdate rather than failing the tick.
+func updateGraphStats(collector *graphstats.Collector) tea.Cmd {
 	return func() tea.Msg {
-		// Try to get stats from FalkorDB
-		// For now, return placeholder
+		stats, err := collector.Collect(context.Background())
+		if err != nil {
+			return graphStats{lastUpdate: fmt.Sprintf("error: %v", err)}
+		}
+		metrics.SetGraphStats(stats.NodeCount, stats.EdgeCount)
 		return graphStats{
-			nodeCount:  0,
-			edgeCount:  0,
-			lastUpdate: "Never",
+			nodeCount:   stats.NodeCount,
+			edgeCount:   stats.EdgeCount,
+			memoryUsage: stats.MemoryUsage,
+			slowQueries: stats.SlowQueries,
+			lastUpdate:  stats.LastUpdate,
 		}
 	}
 }
@@ -1830,19 +2143,128 @@ func formatBytes(bytes uint64) string {
 }
 
 func main() {
-	p := tea.NewProgram(initialModel(), tea.WithAltScreen())
+	if addr := parseMetricsAddr(os.Args[1:]); addr != "" {
+		go func() {
+			if err := metrics.Serve(addr); err != nil {
+				fmt.Fprintf(os.Stderr, "metrics: %v\n", err)
+			}
+		}()
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "--plain" {
+		runPlainStartup()
+		return
+	}
+
+	format, headless := parseStatsFlags(os.Args[1:])
+	if headless {
+		runHeadlessStats(format)
+		return
+	}
+
+	p := tea.NewProgram(initialModel(format), tea.WithAltScreen())
 	if _, err := p.Run(); err != nil {
 		fmt.Printf("Error: %v\n", err)
 		os.Exit(1)
 	}
 }
 
+// parseMetricsAddr reads `--metrics-addr=<host:port>` out of args, the same
+// plain os.Args style parseStatsFlags uses. An empty return means the
+// Prometheus exporter is disabled, which is the default.
+func parseMetricsAddr(args []string) string {
+	for _, a := range args {
+		if strings.HasPrefix(a, "--metrics-addr=") {
+			return strings.TrimPrefix(a, "--metrics-addr=")
+		}
+	}
+	return ""
+}
+
+// parseStatsFlags reads `--format=<value>` and `--stats` out of args, the
+// way the rest of main's flag handling sticks to plain os.Args checks
+// instead of the flag package. `--format` alone just sets the TUI Stats
+// view's initial render mode; `--stats` additionally runs headless (no
+// TUI), printing one record per update to stdout.
+func parseStatsFlags(args []string) (formatter.Format, bool) {
+	format := formatter.TableFormat
+	headless := false
+	for _, a := range args {
+		switch {
+		case strings.HasPrefix(a, "--format="):
+			format = formatter.Format(strings.TrimPrefix(a, "--format="))
+		case a == "--stats":
+			headless = true
+		}
+	}
+	return format, headless
+}
+
+// runHeadlessStats streams container stats via the Engine API (the same
+// collector the TUI's Stats view uses) and prints each update through
+// formatter.Render, one record per tick, so a json-stream format can feed a
+// log aggregator or a Prometheus textfile collector without the TUI
+// running at all.
+func runHeadlessStats(format formatter.Format) {
+	updates := make(chan containerstats.Update, 256)
+	if err := containerstats.Start(context.Background(), updates); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	for u := range updates {
+		if u.Removed {
+			continue
+		}
+		ctx := formatter.StatsFormatContext{
+			Name:        u.Name,
+			CPUPercent:  u.Stat.CPUPercent,
+			MemPercent:  u.Stat.MemPercent,
+			MemUsageStr: u.Stat.MemUsage,
+			NetRx:       u.Stat.NetRx,
+			NetTx:       u.Stat.NetTx,
+			BlockRead:   u.Stat.BlockRead,
+			BlockWrite:  u.Stat.BlockWrite,
+			Pids:        u.Stat.Pids,
+			Status:      u.Stat.Status,
+		}
+		out, err := formatter.Render(format, []formatter.StatsFormatContext{ctx})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			continue
+		}
+		fmt.Print(out)
+	}
+}
+
+// runPlainStartup is the --plain fallback for non-TTY environments (CI,
+// log aggregators): it drives the same performStartup used by the TUI but
+// with a plain progress.Writer that emits one line per event to stdout
+// instead of rendering an in-place vertex list.
+func runPlainStartup() {
+	pw := progress.NewWriter(os.Stdout, true)
+	if err := performStartup(context.Background(), pw, []string{"falkordb", "ollama", "graphiti-mcp"}, loadProbeConfig()); err != nil {
+		os.Exit(1)
+	}
+}
+
 func (m model) renderPipelineView() string {
+	statLine := "Engine stats: Collecting...\n"
+	if stat, ok := m.containerStats[getContainerName("Atlas Engine")]; ok {
+		ctx := containerStatContext("atlas-engine", stat)
+		out, err := formatter.Render(m.outputFormat, []formatter.StatsFormatContext{ctx})
+		if err != nil {
+			statLine = fmt.Sprintf("Engine stats: format error: %v\n", err)
+		} else {
+			statLine = "Engine stats:\n" + out
+		}
+	}
+
 	pipelineBox := boxStyle.Render(
 		"‚öôÔ∏è  Processing Pipeline\n\n" +
 			"Status: Check Atlas Engine logs\n" +
 			"Checkpoints: /app/processed/checkpoints\n" +
 			"Mode: Start Fresh / Resume\n" +
+			"\n" + statLine +
 			"\n" + m.renderHelp("Pipeline"),
 	)
 	return pipelineBox + "\n"
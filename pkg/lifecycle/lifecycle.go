@@ -0,0 +1,316 @@
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/KumarVandit/data_hackathon/internal/composegraph"
+	"github.com/KumarVandit/data_hackathon/internal/dockerapi"
+	"github.com/KumarVandit/data_hackathon/internal/probe"
+)
+
+// CoreServices are the services a from-scratch startup brings up: the
+// dependency-free datastores plus the MCP server everything else depends
+// on.
+var CoreServices = []string{"falkordb", "ollama", "graphiti-mcp"}
+
+// noLog is used wherever a caller doesn't care about progress lines.
+func noLog(string) {}
+
+// StartupOrder derives the flat startup order for targets and their
+// transitive dependencies from docker-compose.yml's depends_on graph via a
+// Kahn-style topological sort, falling back to targets unchanged if the
+// compose file can't be parsed.
+func StartupOrder(targets []string) []string {
+	project, err := LoadProject()
+	if err != nil {
+		return targets
+	}
+	graph := composegraph.New(project)
+
+	needed := make(map[string]bool, len(targets))
+	for _, t := range targets {
+		needed[t] = true
+		for _, dep := range graph.TransitiveDeps(t) {
+			needed[dep.Service] = true
+		}
+	}
+
+	var order []string
+	for _, batch := range graph.Batches() {
+		for _, name := range batch {
+			if needed[name] {
+				order = append(order, name)
+			}
+		}
+	}
+	return order
+}
+
+// Up starts every service in serviceNames that isn't already running, in
+// compose-dependency order. log receives a line per step, the way
+// dockerapi.Up's own log callback does.
+func Up(ctx context.Context, serviceNames []string, log func(string)) (string, error) {
+	if log == nil {
+		log = noLog
+	}
+	project, err := LoadProject()
+	if err != nil {
+		return "", fmt.Errorf("lifecycle: %w", err)
+	}
+
+	var started []string
+	for _, name := range StartupOrder(serviceNames) {
+		if dockerapi.IsRunning(ctx, name) {
+			log(fmt.Sprintf("%s already running", name))
+			continue
+		}
+		if err := dockerapi.Up(ctx, project, []string{name}, log); err != nil {
+			return "", fmt.Errorf("lifecycle: start %s: %w", name, err)
+		}
+		started = append(started, name)
+	}
+	if len(started) == 0 {
+		return "all services already running", nil
+	}
+	return fmt.Sprintf("started: %s", strings.Join(started, ", ")), nil
+}
+
+// Down stops and removes every compose service.
+func Down(ctx context.Context, log func(string)) (string, error) {
+	if log == nil {
+		log = noLog
+	}
+	project, err := LoadProject()
+	if err != nil {
+		return "", fmt.Errorf("lifecycle: %w", err)
+	}
+	if err := dockerapi.Down(ctx, project, project.ServiceNames(), log); err != nil {
+		return "", fmt.Errorf("lifecycle: %w", err)
+	}
+	return "services stopped", nil
+}
+
+// Build builds every compose service's image and starts it.
+func Build(ctx context.Context, log func(string)) (string, error) {
+	if log == nil {
+		log = noLog
+	}
+	project, err := LoadProject()
+	if err != nil {
+		return "", fmt.Errorf("lifecycle: %w", err)
+	}
+	names := project.ServiceNames()
+	if err := dockerapi.Build(ctx, project, names, log); err != nil {
+		return "", fmt.Errorf("lifecycle: build: %w", err)
+	}
+	if err := dockerapi.Up(ctx, project, names, log); err != nil {
+		return "", fmt.Errorf("lifecycle: up: %w", err)
+	}
+	return "services built and started", nil
+}
+
+// StartWithDeps starts serviceName after starting (and waiting on, per each
+// edge's compose depends_on condition) everything it transitively depends
+// on, walking the graph the same way chunk1-4's composegraph package
+// derives it from docker-compose.yml.
+func StartWithDeps(ctx context.Context, serviceName string, probeCfg *probe.Config, log func(string)) (string, error) {
+	if log == nil {
+		log = noLog
+	}
+	project, err := LoadProject()
+	if err != nil {
+		return "", fmt.Errorf("lifecycle: %w", err)
+	}
+
+	graph := composegraph.New(project)
+	for _, dep := range graph.TransitiveDeps(serviceName) {
+		if dockerapi.IsRunning(ctx, dep.Service) {
+			continue
+		}
+		if err := dockerapi.Up(ctx, project, []string{dep.Service}, log); err != nil {
+			return "", fmt.Errorf("lifecycle: start dependency %s: %w", dep.Service, err)
+		}
+		if err := waitForCondition(ctx, dep.Service, dep.Condition, probeCfg); err != nil {
+			return "", fmt.Errorf("lifecycle: wait for %s: %w", dep.Service, err)
+		}
+	}
+
+	if err := dockerapi.Up(ctx, project, []string{serviceName}, log); err != nil {
+		return "", fmt.Errorf("lifecycle: start %s: %w", serviceName, err)
+	}
+	return fmt.Sprintf("%s started", serviceName), nil
+}
+
+// Toggle starts serviceName (and its dependencies) if it's stopped, or
+// stops it if it's running.
+func Toggle(ctx context.Context, serviceName string, probeCfg *probe.Config, log func(string)) (string, error) {
+	if dockerapi.IsRunning(ctx, serviceName) {
+		if err := dockerapi.Stop(ctx, serviceName, 10*time.Second); err != nil {
+			return "", fmt.Errorf("lifecycle: stop %s: %w", serviceName, err)
+		}
+		return fmt.Sprintf("%s stopped", serviceName), nil
+	}
+	return StartWithDeps(ctx, serviceName, probeCfg, log)
+}
+
+// Restart restarts a single service by name.
+func Restart(ctx context.Context, serviceName string) (string, error) {
+	if err := dockerapi.Restart(ctx, serviceName, 10*time.Second); err != nil {
+		return "", fmt.Errorf("lifecycle: restart %s: %w", serviceName, err)
+	}
+	return fmt.Sprintf("%s restarted", serviceName), nil
+}
+
+// StartProcessing ensures graphiti-mcp and falkordb are up, then starts the
+// Atlas Engine processing pipeline, surfacing its recent logs if it exits
+// right back out.
+func StartProcessing(ctx context.Context, probeCfg *probe.Config, log func(string)) (string, error) {
+	if log == nil {
+		log = noLog
+	}
+	if !dockerapi.IsRunning(ctx, "graphiti-mcp") || !dockerapi.IsRunning(ctx, "falkordb") {
+		if _, err := StartWithDeps(ctx, "graphiti-mcp", probeCfg, log); err != nil {
+			return "", err
+		}
+	}
+
+	project, err := LoadProject()
+	if err != nil {
+		return "", fmt.Errorf("lifecycle: %w", err)
+	}
+	if err := dockerapi.Up(ctx, project, []string{"atlas-engine"}, log); err != nil {
+		return "", fmt.Errorf("lifecycle: start atlas-engine: %w", err)
+	}
+
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case <-time.After(3 * time.Second):
+	}
+
+	if !dockerapi.IsRunning(ctx, "atlas-engine") {
+		logLines, _ := dockerapi.Logs(ctx, "atlas-engine", 30)
+		logStr := strings.Join(logLines, " | ")
+		if len(logStr) > 400 {
+			logStr = logStr[:400] + "..."
+		}
+		msg := "atlas-engine container failed to start"
+		if logStr != "" {
+			msg += fmt.Sprintf("; logs: %s", logStr)
+		}
+		return "", fmt.Errorf("lifecycle: %s", msg)
+	}
+
+	return "processing pipeline started (check logs for progress)", nil
+}
+
+// StartDashboard ensures graphiti-mcp and falkordb are up, then builds and
+// starts atlas-dashboard.
+func StartDashboard(ctx context.Context, probeCfg *probe.Config, log func(string)) (string, error) {
+	if log == nil {
+		log = noLog
+	}
+	if !dockerapi.IsRunning(ctx, "graphiti-mcp") || !dockerapi.IsRunning(ctx, "falkordb") {
+		if _, err := StartWithDeps(ctx, "graphiti-mcp", probeCfg, log); err != nil {
+			return "", err
+		}
+	}
+
+	project, err := LoadProject()
+	if err != nil {
+		return "", fmt.Errorf("lifecycle: %w", err)
+	}
+	if err := dockerapi.Build(ctx, project, []string{"atlas-dashboard"}, log); err != nil {
+		return "", fmt.Errorf("lifecycle: build atlas-dashboard: %w", err)
+	}
+	if err := dockerapi.Up(ctx, project, []string{"atlas-dashboard"}, log); err != nil {
+		return "", fmt.Errorf("lifecycle: start atlas-dashboard: %w", err)
+	}
+
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case <-time.After(3 * time.Second):
+	}
+
+	return "dashboard started at http://localhost:5173 (API: http://localhost:8001)", nil
+}
+
+// waitForCondition blocks until dep satisfies the depends_on condition
+// compose-go attached to it: ConditionHealthy waits out its Spec's
+// InitialDelaySeconds, then polls dep's full readiness probe every
+// PeriodSeconds, tracking consecutive passes/fails against
+// SuccessThreshold/FailureThreshold the same way a kubelet does, while
+// ConditionStarted and ConditionCompletedSuccessfully just wait for the
+// container to be running, matching what `docker compose` itself checks
+// for each condition.
+func waitForCondition(ctx context.Context, dep string, cond composegraph.Condition, probeCfg *probe.Config) error {
+	timeout := 30 * time.Second
+	interval := 1 * time.Second
+	if cond == composegraph.ConditionHealthy {
+		timeout = 60 * time.Second
+		interval = 2 * time.Second
+	}
+
+	successThreshold, failureThreshold := 1, 0
+	if spec, ok := probeCfg.Services[dep]; ok {
+		if spec.SuccessThreshold > 0 {
+			successThreshold = spec.SuccessThreshold
+		}
+		failureThreshold = spec.FailureThreshold
+		if spec.PeriodSeconds > 0 {
+			interval = time.Duration(spec.PeriodSeconds) * time.Second
+		}
+		if cond == composegraph.ConditionHealthy && spec.InitialDelaySeconds > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Duration(spec.InitialDelaySeconds) * time.Second):
+			}
+		}
+	}
+	var consecutivePasses, consecutiveFails int
+
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		var ready bool
+		if cond == composegraph.ConditionHealthy {
+			if checkReady(ctx, dep, probeCfg) {
+				consecutivePasses++
+				consecutiveFails = 0
+				ready = consecutivePasses >= successThreshold
+			} else {
+				consecutivePasses = 0
+				consecutiveFails++
+				if failureThreshold > 0 && consecutiveFails >= failureThreshold {
+					return fmt.Errorf("lifecycle: %s failed readiness probe %d consecutive times", dep, consecutiveFails)
+				}
+			}
+		} else {
+			ready = dockerapi.IsRunning(ctx, dep)
+		}
+		if ready {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+	return fmt.Errorf("lifecycle: %s did not become ready within %s", dep, timeout)
+}
+
+// checkReady reports whether dep is ready: if a probe.Spec is configured
+// for it, every probe in the spec is run once; otherwise this falls back
+// to Docker's own health field.
+func checkReady(ctx context.Context, dep string, probeCfg *probe.Config) bool {
+	if spec, ok := probeCfg.Services[dep]; ok {
+		return spec.CheckOnce(ctx, dep)
+	}
+	info, ok, err := dockerapi.Inspect(ctx, dep)
+	return err == nil && ok && (info.Health == "healthy" || info.Health == "running")
+}
@@ -0,0 +1,36 @@
+// Package lifecycle holds the Docker Compose service lifecycle logic the
+// Atlas Control Panel TUI and the headless atlasctl CLI both need: starting
+// a service and its compose dependencies, stopping/building/restarting a
+// service, and reporting live status. The lifecycle operations (Up, Down,
+// Build, StartWithDeps, Toggle, Restart, StartProcessing, StartDashboard)
+// return a plain (string, error) so a caller can render it however it
+// likes — a statusMsg in the TUI, or a line of stdout from atlasctl; Status
+// and Logs return plain data.
+package lifecycle
+
+import (
+	"sync"
+
+	composetypes "github.com/compose-spec/compose-go/types"
+
+	"github.com/KumarVandit/data_hackathon/internal/dockerapi"
+)
+
+// ComposeFilePath is the compose file every lifecycle operation reads,
+// relative to the process's working directory.
+const ComposeFilePath = "docker-compose.yml"
+
+var (
+	projectOnce sync.Once
+	project     *composetypes.Project
+	projectErr  error
+)
+
+// LoadProject parses ComposeFilePath once per process and caches the
+// result, the same way the TUI's own loadComposeProject does.
+func LoadProject() (*composetypes.Project, error) {
+	projectOnce.Do(func() {
+		project, projectErr = dockerapi.LoadProject(ComposeFilePath)
+	})
+	return project, projectErr
+}
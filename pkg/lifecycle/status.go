@@ -0,0 +1,58 @@
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/KumarVandit/data_hackathon/internal/dockerapi"
+)
+
+// ServiceStatus is the live status of one compose service: the same
+// uptime/health/container-ID fields the TUI's Services view renders from
+// its services[i] slice, made available as plain data for `atlasctl status
+// --json`.
+type ServiceStatus struct {
+	Name        string `json:"name"`
+	Running     bool   `json:"running"`
+	Health      string `json:"health"`
+	Uptime      string `json:"uptime"`
+	ContainerID string `json:"container_id"`
+}
+
+// Status returns the live status of every service declared in
+// docker-compose.yml, sorted by name.
+func Status(ctx context.Context) ([]ServiceStatus, error) {
+	project, err := LoadProject()
+	if err != nil {
+		return nil, fmt.Errorf("lifecycle: %w", err)
+	}
+	names := project.ServiceNames()
+	sort.Strings(names)
+
+	statuses := make([]ServiceStatus, 0, len(names))
+	for _, name := range names {
+		info, ok, err := dockerapi.Inspect(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("lifecycle: inspect %s: %w", name, err)
+		}
+		status := ServiceStatus{Name: name, Health: "not created"}
+		if ok {
+			status.Running = info.Status == "running"
+			status.Health = info.Health
+			status.Uptime = info.Uptime
+			status.ContainerID = info.ID
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// Logs returns the last n lines of serviceName's container log.
+func Logs(ctx context.Context, serviceName string, tail int) ([]string, error) {
+	lines, err := dockerapi.Logs(ctx, serviceName, tail)
+	if err != nil {
+		return nil, fmt.Errorf("lifecycle: %w", err)
+	}
+	return lines, nil
+}